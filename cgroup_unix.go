@@ -0,0 +1,173 @@
+//go:build !darwin && !windows
+
+package sysinfo
+
+import (
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// cgroupCPUs will return the number of CPUs available to the current
+// cgroup, based on the CFS quota and the cpuset.
+func cgroupCPUs() (float64, bool) {
+	var cpus float64
+	var fields []string
+	var period float64
+	var quota float64
+	var tmp string
+
+	// cgroup v2 uses "$MAX $PERIOD" while v1 uses separate files
+	if tmp = cgroupRead("cpu", "", "cpu.max"); tmp != "" {
+		//nolint:mnd // $MAX $PERIOD == 2 fields
+		if fields = strings.Fields(tmp); len(fields) == 2 {
+			quota, _ = strconv.ParseFloat(fields[0], 64)
+			period, _ = strconv.ParseFloat(fields[1], 64)
+		}
+	} else {
+		tmp = cgroupRead("cpu", "cpu.cfs_quota_us", "")
+		quota, _ = strconv.ParseFloat(tmp, 64)
+
+		tmp = cgroupRead("cpu", "cpu.cfs_period_us", "")
+		period, _ = strconv.ParseFloat(tmp, 64)
+	}
+
+	if (quota > 0) && (period > 0) {
+		cpus = quota / period
+	}
+
+	tmp = cgroupRead("cpuset", "cpuset.cpus", "cpuset.cpus.effective")
+	if n := countCPUList(tmp); n > 0 {
+		if (cpus == 0) || (n < cpus) {
+			cpus = n
+		}
+	}
+
+	if cpus == 0 {
+		return 0, false
+	}
+
+	//nolint:mnd // Round to 2 decimal places
+	return math.Round(cpus*100) / 100, true
+}
+
+// cgroupMemory will return the memory usage and limit, in bytes, of
+// the current cgroup.
+func cgroupMemory() (int, int, bool) {
+	var e error
+	var limit int
+	var tmp string
+	var used int
+
+	tmp = cgroupRead(
+		"memory",
+		"memory.limit_in_bytes",
+		"memory.max",
+	)
+	if limit, e = strconv.Atoi(tmp); (e != nil) || (limit <= 0) {
+		// Also catches "max" which means no limit
+		return 0, 0, false
+	}
+
+	tmp = cgroupRead(
+		"memory",
+		"memory.usage_in_bytes",
+		"memory.current",
+	)
+	if used, e = strconv.Atoi(tmp); e != nil {
+		return 0, 0, false
+	}
+
+	return used, limit, true
+}
+
+// cgroupRead will read the specified cgroup file for the current
+// process. The v2 file is preferred, falling back to the v1 file
+// under the named controller. If the process cgroup isn't visible
+// (e.g. inside a container with its own namespace), the controller
+// root is tried instead.
+func cgroupRead(ctrl string, v1 string, v2 string) string {
+	var cgroups string = readTrim("/proc/self/cgroup")
+	var cols []string
+	var root string = "/sys/fs/cgroup"
+	var tmp string
+
+	for _, line := range strings.Split(cgroups, "\n") {
+		//nolint:mnd // ID:controllers:path == 3 fields
+		if cols = strings.SplitN(line, ":", 3); len(cols) != 3 {
+			continue
+		}
+
+		switch {
+		case (cols[0] == "0") && (cols[1] == "") && (v2 != ""):
+			for _, dir := range []string{
+				filepath.Join(root, cols[2]),
+				root,
+			} {
+				if tmp = readTrim(filepath.Join(dir, v2)); tmp != "" {
+					return tmp
+				}
+			}
+		case (v1 != "") && hasController(cols[1], ctrl):
+			for _, dir := range []string{
+				filepath.Join(root, ctrl, cols[2]),
+				filepath.Join(root, ctrl),
+			} {
+				if tmp = readTrim(filepath.Join(dir, v1)); tmp != "" {
+					return tmp
+				}
+			}
+		}
+	}
+
+	return ""
+}
+
+// countCPUList will return the number of CPUs in a list such as
+// "0-3,6,8-9".
+func countCPUList(list string) float64 {
+	var after string
+	var before string
+	var count float64
+	var e error
+	var found bool
+	var hi int
+	var lo int
+
+	for _, r := range strings.Split(list, ",") {
+		if r = strings.TrimSpace(r); r == "" {
+			continue
+		}
+
+		before, after, found = strings.Cut(r, "-")
+
+		if lo, e = strconv.Atoi(before); e != nil {
+			return 0
+		}
+
+		hi = lo
+
+		if found {
+			if hi, e = strconv.Atoi(after); e != nil {
+				return 0
+			}
+		}
+
+		count += float64(hi - lo + 1)
+	}
+
+	return count
+}
+
+// hasController will return whether or not the comma-separated list
+// of cgroup v1 controllers contains the specified controller.
+func hasController(list string, ctrl string) bool {
+	for _, c := range strings.Split(list, ",") {
+		if c == ctrl {
+			return true
+		}
+	}
+
+	return false
+}
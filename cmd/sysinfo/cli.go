@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/mjwhitta/cli"
 	hl "github.com/mjwhitta/hilighter"
+	"github.com/mjwhitta/log"
 	"github.com/mjwhitta/sysinfo"
 )
 
@@ -23,8 +25,12 @@ const (
 
 // Flags
 var flags struct {
+	colors  string
 	fields  cli.StringList
+	json    bool
+	lang    string
 	nocolor bool
+	serials bool
 	verbose bool
 	version bool
 }
@@ -54,29 +60,71 @@ func init() {
 		"FIELDS",
 		":",
 		"blank:Blank line\n",
+		"boot:Boot time and how the previous boot ended\n",
 		"colors:Sample of terminal colors\n",
 		"cpu:CPU info\n",
+		"de:Desktop environment\n",
+		"disks:Block devices, type, partition table, and health\n",
+		"dns:DNS nameservers and search domains\n",
+		"firmware:BIOS/UEFI firmware info\n",
 		"fs:Filesystem usage\n",
+		"gateway:Default gateways\n",
+		"gpu:PCI display adapters and kernel drivers\n",
 		"host:Hostname\n",
+		"init:Init system (PID 1)\n",
 		"ip:IPv4/IPv6 addresses\n",
-		"kernel:Kernel info\n",
+		"kernel:Kernel info (kernel:detail adds build, cmdline,",
+		"taint, modules, and security modules)\n",
+		"model:Hardware vendor and model\n",
+		"net:Network interface details\n",
 		"os:Operating System info\n",
+		"packages:Installed package counts\n",
+		"ports:Listening ports and owning processes\n",
+		"public_ip:Public IP (opt-in, queries public_ip_source)\n",
 		"ram:RAM usage\n",
-		"shell:Current shell\n",
+		"reboot:Pending reboot after kernel or library updates\n",
+		"services:Running and failed systemd units (via D-Bus)\n",
+		"session:Graphical session type (Wayland/X11)\n",
+		"shell:Current shell and version\n",
+		"storage:RAID, LVM, ZFS, and btrfs status\n",
+		"term:Terminal type, size, and color support\n",
+		"terminal:Terminal emulator or multiplexer\n",
 		"tty:TTY info\n",
-		"uptime:Uptime",
+		"uptime:Uptime (see uptime_style)\n",
+		"user:Current user, groups, and privileges\n",
+		"users:Logged-in users and current user identity\n",
+		"virt:Virtualization or container type\n",
+		"vulns:CPU vulnerability mitigations and microcode\n",
+		"wifi:Wi-Fi SSID, signal, band, and link rate\n",
+		"wm:Window manager or compositor",
 	)
 
 	cli.Title = "SysInfo"
 
 	// Parse cli flags
+	cli.Flag(
+		&flags.colors,
+		"colors",
+		"",
+		"Use the specified palette for the colors field (16, 256,",
+		"truecolor, or gradient).",
+	)
 	cli.Flag(
 		&flags.fields,
 		"f",
 		"field",
 		"Show specified field. Can be used more than once. By",
-		"default, all fields are shown. Use this flag to adjust the",
-		"order.",
+		"default, host, os, kernel, uptime, ip, shell, tty, cpu,",
+		"ram, fs, and colors are shown. Use this flag to show other",
+		"fields or to adjust the order.",
+	)
+	cli.Flag(&flags.json, "j", "json", false, "Output JSON.")
+	cli.Flag(
+		&flags.lang,
+		"lang",
+		"",
+		"Use the specified language for labels (de, en, es, or fr).",
+		"Defaults to LC_ALL, LC_MESSAGES, or LANG.",
 	)
 	cli.Flag(
 		&flags.nocolor,
@@ -84,6 +132,12 @@ func init() {
 		false,
 		"Disable colorized output.",
 	)
+	cli.Flag(
+		&flags.serials,
+		"serials",
+		false,
+		"Include hardware serial numbers in JSON output.",
+	)
 	cli.Flag(
 		&flags.verbose,
 		"v",
@@ -97,7 +151,100 @@ func init() {
 
 // Process cli flags and ensure no issues
 func validate() {
+	var d time.Duration
+	var e error
+
+	// Disable colors if not writing to a terminal
+	if !sysinfo.IsTerminal(os.Stdout) {
+		flags.nocolor = true
+	}
+
 	hl.Disable(flags.nocolor)
+	sysinfo.BytePrecision = *cfg.BytePrecision
+	sysinfo.ExcludeDisks = cfg.ExcludeDisks
+	sysinfo.ExcludeInterfaces = cfg.ExcludeInterfaces
+	sysinfo.HideLoopbackPorts = cfg.HideLoopbackPorts
+	sysinfo.IncludeInterfaces = cfg.IncludeInterfaces
+	sysinfo.Labels = cfg.Labels
+	sysinfo.Language = flags.lang
+	sysinfo.PublicIPSource = cfg.PublicIPSource
+	sysinfo.ShowSerials = flags.serials
+	sysinfo.SystemBus = cfg.SystemBus
+
+	// Palette from cli takes precedence over cfg
+	if flags.colors == "" {
+		flags.colors = cfg.Palette
+	}
+
+	switch flags.colors {
+	case "16", "256", "gradient", "truecolor":
+		sysinfo.Palette = flags.colors
+	default:
+		log.ErrXf(
+			InvalidOption,
+			"invalid palette: %s",
+			flags.colors,
+		)
+	}
+
+	switch cfg.Glyphs {
+	case "bars", "blocks", "circles":
+		sysinfo.Glyphs = cfg.Glyphs
+	default:
+		log.ErrXf(
+			InvalidOption,
+			"invalid glyphs in cfg: %s",
+			cfg.Glyphs,
+		)
+	}
+
+	switch cfg.ByteUnits {
+	case "auto", "iec", "si":
+	case "B", "KiB", "MiB", "GiB", "TiB", "kB", "MB", "GB", "TB":
+	case "K", "M", "G", "T":
+	default:
+		log.ErrXf(
+			InvalidOption,
+			"invalid byte_units in cfg: %s",
+			cfg.ByteUnits,
+		)
+	}
+
+	sysinfo.ByteUnits = cfg.ByteUnits
+
+	switch cfg.UptimeStyle {
+	case "iso8601", "long", "short":
+		sysinfo.UptimeStyle = cfg.UptimeStyle
+	default:
+		log.ErrXf(
+			InvalidOption,
+			"invalid uptime_style in cfg: %s",
+			cfg.UptimeStyle,
+		)
+	}
+
+	// Zero disables the cache
+	d, e = time.ParseDuration(cfg.PublicIPCacheTTL)
+	if (e != nil) || (d < 0) {
+		log.ErrXf(
+			InvalidOption,
+			"invalid public_ip_cache_ttl in cfg: %s",
+			cfg.PublicIPCacheTTL,
+		)
+	}
+
+	sysinfo.PublicIPCacheTTL = d
+
+	d, e = time.ParseDuration(cfg.PublicIPTimeout)
+	if (e != nil) || (d <= 0) {
+		log.ErrXf(
+			InvalidOption,
+			"invalid public_ip_timeout in cfg: %s",
+			cfg.PublicIPTimeout,
+		)
+	}
+
+	sysinfo.PublicIPTimeout = d
 
 	// Short circuit if version was requested
 	if flags.version {
@@ -8,11 +8,28 @@ import (
 	"strings"
 
 	"github.com/mjwhitta/errors"
+	"github.com/mjwhitta/sysinfo"
 )
 
 type config struct {
-	DataColors  []string `json:"data_colors"`
-	FieldColors []string `json:"field_colors"`
+	BytePrecision     *int              `json:"byte_precision"`
+	ByteUnits         string            `json:"byte_units"`
+	CritColors        []string          `json:"crit_colors"`
+	DataColors        []string          `json:"data_colors"`
+	ExcludeDisks      []string          `json:"exclude_disks"`
+	ExcludeInterfaces []string          `json:"exclude_interfaces"`
+	FieldColors       []string          `json:"field_colors"`
+	Glyphs            string            `json:"glyphs"`
+	HideLoopbackPorts bool              `json:"hide_loopback_ports"`
+	IncludeInterfaces []string          `json:"include_interfaces"`
+	Labels            map[string]string `json:"labels"`
+	Palette           string            `json:"palette"`
+	PublicIPCacheTTL  string            `json:"public_ip_cache_ttl"`
+	PublicIPSource    string            `json:"public_ip_source"`
+	PublicIPTimeout   string            `json:"public_ip_timeout"`
+	SystemBus         string            `json:"system_bus"`
+	UptimeStyle       string            `json:"uptime_style"`
+	WarnColors        []string          `json:"warn_colors"`
 
 	file string
 }
@@ -34,9 +51,24 @@ func init() {
 	if (e != nil) || (len(bytes.TrimSpace(b)) == 0) {
 		// Default cfg
 		cfg = &config{
-			DataColors:  []string{"green"},
-			FieldColors: []string{"blue"},
-			file:        fn,
+			BytePrecision:     &sysinfo.BytePrecision,
+			ByteUnits:         sysinfo.ByteUnits,
+			CritColors:        []string{"red"},
+			DataColors:        []string{"green"},
+			ExcludeDisks:      sysinfo.ExcludeDisks,
+			ExcludeInterfaces: sysinfo.ExcludeInterfaces,
+			FieldColors:       []string{"blue"},
+			Glyphs:            "blocks",
+			IncludeInterfaces: []string{},
+			Labels:            map[string]string{},
+			Palette:           "16",
+			PublicIPCacheTTL:  sysinfo.PublicIPCacheTTL.String(),
+			PublicIPSource:    sysinfo.PublicIPSource,
+			PublicIPTimeout:   sysinfo.PublicIPTimeout.String(),
+			SystemBus:         sysinfo.SystemBus,
+			UptimeStyle:       sysinfo.UptimeStyle,
+			WarnColors:        []string{"yellow"},
+			file:              fn,
 		}
 
 		if e = cfg.save(); e != nil {
@@ -48,13 +80,69 @@ func init() {
 		}
 	}
 
+	if cfg.BytePrecision == nil {
+		cfg.BytePrecision = &sysinfo.BytePrecision
+	}
+
+	if cfg.ByteUnits == "" {
+		cfg.ByteUnits = sysinfo.ByteUnits
+	}
+
+	if cfg.CritColors == nil {
+		cfg.CritColors = []string{"red"}
+	}
+
 	if cfg.DataColors == nil {
 		cfg.DataColors = []string{"green"}
 	}
 
+	if cfg.ExcludeDisks == nil {
+		cfg.ExcludeDisks = sysinfo.ExcludeDisks
+	}
+
+	if cfg.ExcludeInterfaces == nil {
+		cfg.ExcludeInterfaces = sysinfo.ExcludeInterfaces
+	}
+
 	if cfg.FieldColors == nil {
 		cfg.FieldColors = []string{"blue"}
 	}
+
+	if cfg.Glyphs == "" {
+		cfg.Glyphs = "blocks"
+	}
+
+	if cfg.IncludeInterfaces == nil {
+		cfg.IncludeInterfaces = []string{}
+	}
+
+	if cfg.Labels == nil {
+		cfg.Labels = map[string]string{}
+	}
+
+	if cfg.Palette == "" {
+		cfg.Palette = "16"
+	}
+
+	if cfg.PublicIPCacheTTL == "" {
+		cfg.PublicIPCacheTTL = sysinfo.PublicIPCacheTTL.String()
+	}
+
+	if cfg.PublicIPSource == "" {
+		cfg.PublicIPSource = sysinfo.PublicIPSource
+	}
+
+	if cfg.PublicIPTimeout == "" {
+		cfg.PublicIPTimeout = sysinfo.PublicIPTimeout.String()
+	}
+
+	if cfg.UptimeStyle == "" {
+		cfg.UptimeStyle = sysinfo.UptimeStyle
+	}
+
+	if cfg.WarnColors == nil {
+		cfg.WarnColors = []string{"yellow"}
+	}
 }
 
 func (c *config) save() error {
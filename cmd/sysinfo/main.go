@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/mjwhitta/log"
@@ -23,13 +24,28 @@ func main() {
 		}
 	}()
 
+	var b []byte
+	var e error
 	var s *sysinfo.SysInfo
 
 	validate()
 
 	s = sysinfo.New(flags.fields...)
+
+	if flags.json {
+		if b, e = json.MarshalIndent(s, "", "  "); e != nil {
+			panic(e)
+		}
+
+		fmt.Println(string(b))
+
+		return
+	}
+
+	s.SetCritColors(cfg.CritColors...)
 	s.SetDataColors(cfg.DataColors...)
 	s.SetFieldColors(cfg.FieldColors...)
+	s.SetWarnColors(cfg.WarnColors...)
 
 	if s.String() != "" {
 		fmt.Println(s)
@@ -0,0 +1,195 @@
+package sysinfo
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	hl "github.com/mjwhitta/hilighter"
+)
+
+// palette will return a sample of terminal colors using the
+// configured Palette and Glyphs styles. Palettes the terminal can't
+// display are downgraded, e.g. truecolor to the 256 color cube.
+func (s *SysInfo) palette() string {
+	var lines []string
+	var palette string = strings.ToLower(Palette)
+
+	if (palette == "gradient") || (palette == "truecolor") {
+		if s.depth < colorsTrue {
+			palette = "256"
+		}
+	}
+
+	if (palette == "256") && (s.depth < colors256) {
+		palette = "16"
+	}
+
+	switch palette {
+	case "256":
+		lines = palette256()
+	case "gradient":
+		//nolint:mnd // RGB values
+		lines = []string{
+			gradient([3]float64{0, 0, 0}, [3]float64{255, 0, 0}),
+			gradient([3]float64{0, 0, 0}, [3]float64{0, 255, 0}),
+			gradient([3]float64{0, 0, 0}, [3]float64{0, 0, 255}),
+			gradient([3]float64{0, 0, 0}, [3]float64{255, 255, 255}),
+		}
+	case "truecolor":
+		lines = []string{spectrum()}
+	default:
+		lines = []string{palette16()}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// glyph will return the character used for a single color cell.
+func glyph() string {
+	switch strings.ToLower(Glyphs) {
+	case "bars":
+		return "▌"
+	case "circles":
+		return "●"
+	default:
+		return "█"
+	}
+}
+
+// gradient will return a strip of cells fading from one RGB color to
+// another.
+func gradient(from [3]float64, to [3]float64) string {
+	var rgb [3]float64
+	var sb strings.Builder
+	var t float64
+
+	for i := range paletteWidth {
+		t = float64(i) / float64(paletteWidth-1)
+
+		for j := range rgb {
+			rgb[j] = from[j] + (to[j]-from[j])*t
+		}
+
+		sb.WriteString(hexCell(rgb))
+	}
+
+	return sb.String()
+}
+
+// hexCell will return a single cell in the specified RGB color.
+func hexCell(rgb [3]float64) string {
+	return hl.Hilight(
+		fmt.Sprintf(
+			"#%02x%02x%02x",
+			uint8(math.Round(rgb[0])),
+			uint8(math.Round(rgb[1])),
+			uint8(math.Round(rgb[2])),
+		),
+		glyph(),
+	)
+}
+
+// palette16 will return the 8 ANSI colors alongside their light
+// variants.
+func palette16() string {
+	var sb strings.Builder
+
+	for _, clr := range []string{
+		"black", "red", "green", "yellow",
+		"blue", "magenta", "cyan", "white",
+	} {
+		switch strings.ToLower(Glyphs) {
+		case "bars":
+			sb.WriteString(hl.Hilight(clr, "██"))
+			sb.WriteString(hl.Hilight("light_"+clr, "██"))
+		case "circles":
+			sb.WriteString(hl.Hilight(clr, "●"))
+			sb.WriteString(hl.Hilight("light_"+clr, "●"))
+			sb.WriteString(" ")
+		default:
+			sb.WriteString(
+				hl.Hilights(
+					[]string{"light_" + clr, "on_" + clr},
+					"▄▄▄",
+				),
+			)
+		}
+	}
+
+	return sb.String()
+}
+
+// palette256 will return the xterm-256 palette: the system colors,
+// the 6x6x6 color cube, and the grayscale ramp.
+//
+//nolint:mnd // xterm-256 layout
+func palette256() []string {
+	var lines []string
+	var sb strings.Builder
+
+	for i := range 16 {
+		sb.WriteString(xtermCell(i))
+	}
+
+	lines = append(lines, sb.String())
+
+	// One line per red value
+	for r := range 6 {
+		sb.Reset()
+
+		for i := range 36 {
+			sb.WriteString(xtermCell(16 + (r * 36) + i))
+		}
+
+		lines = append(lines, sb.String())
+	}
+
+	sb.Reset()
+
+	for i := 232; i < 256; i++ {
+		sb.WriteString(xtermCell(i))
+	}
+
+	return append(lines, sb.String())
+}
+
+// spectrum will return a strip of cells covering the full hue range.
+//
+//nolint:mnd // HSV to RGB conversion
+func spectrum() string {
+	var h float64
+	var rgb [3]float64
+	var sb strings.Builder
+	var x float64
+
+	for i := range paletteWidth {
+		h = 360 * float64(i) / float64(paletteWidth)
+		x = 255 * (1 - math.Abs(math.Mod(h/60, 2)-1))
+
+		switch {
+		case h < 60:
+			rgb = [3]float64{255, x, 0}
+		case h < 120:
+			rgb = [3]float64{x, 255, 0}
+		case h < 180:
+			rgb = [3]float64{0, 255, x}
+		case h < 240:
+			rgb = [3]float64{0, x, 255}
+		case h < 300:
+			rgb = [3]float64{x, 0, 255}
+		default:
+			rgb = [3]float64{255, 0, x}
+		}
+
+		sb.WriteString(hexCell(rgb))
+	}
+
+	return sb.String()
+}
+
+// xtermCell will return a single cell in the specified xterm-256
+// color.
+func xtermCell(i int) string {
+	return hl.Hilight(fmt.Sprintf("color%03d", i), glyph())
+}
@@ -0,0 +1,425 @@
+//go:build linux
+
+package sysinfo
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mjwhitta/errors"
+)
+
+// dbus is a minimal D-Bus client, supporting only method calls with
+// string arguments.
+type dbus struct {
+	conn   net.Conn
+	r      *bufio.Reader
+	serial uint32
+}
+
+// newDBus will connect and authenticate to the system bus.
+func newDBus() (*dbus, error) {
+	var addr string = SystemBus
+	var auth string
+	var d *dbus = &dbus{}
+	var e error
+	var line string
+	var path string
+	var uid string = strconv.Itoa(os.Getuid())
+
+	if addr == "" {
+		addr = os.Getenv("DBUS_SYSTEM_BUS_ADDRESS")
+	}
+
+	if addr == "" {
+		addr = "unix:path=/var/run/dbus/system_bus_socket"
+	}
+
+	if path, e = dbusPath(addr); e != nil {
+		return nil, e
+	}
+
+	d.conn, e = net.DialTimeout("unix", path, time.Second)
+	if e != nil {
+		return nil, errors.Newf(
+			"failed to connect to %s: %w",
+			addr,
+			e,
+		)
+	}
+
+	// Never hang waiting on the bus
+	_ = d.conn.SetDeadline(time.Now().Add(2 * time.Second))
+	d.r = bufio.NewReader(d.conn)
+
+	auth = "\x00AUTH EXTERNAL " + hex.EncodeToString([]byte(uid))
+	if _, e = d.conn.Write([]byte(auth + "\r\n")); e != nil {
+		d.close()
+		return nil, errors.Newf("failed to authenticate: %w", e)
+	}
+
+	if line, e = d.r.ReadString('\n'); e != nil {
+		d.close()
+		return nil, errors.Newf("failed to authenticate: %w", e)
+	} else if !strings.HasPrefix(line, "OK ") {
+		d.close()
+		return nil, errors.Newf(
+			"failed to authenticate: %s",
+			strings.TrimSpace(line),
+		)
+	}
+
+	if _, e = d.conn.Write([]byte("BEGIN\r\n")); e != nil {
+		d.close()
+		return nil, errors.Newf("failed to authenticate: %w", e)
+	}
+
+	// Required before any other method calls
+	_, e = d.call(
+		"org.freedesktop.DBus",
+		"/org/freedesktop/DBus",
+		"org.freedesktop.DBus",
+		"Hello",
+	)
+	if e != nil {
+		d.close()
+		return nil, e
+	}
+
+	return d, nil
+}
+
+// call will call the specified method and return a reader for the
+// body of the reply.
+func (d *dbus) call(
+	dest string, path string, iface string, member string,
+	args ...string,
+) (*dbusReader, error) {
+	var body []byte
+	var e error
+	var msg []byte
+	var r *dbusReader
+	var start int
+
+	d.serial++
+
+	for _, arg := range args {
+		body = dbusString(dbusAlign(body, 4), arg) //nolint:mnd // u32
+	}
+
+	msg = []byte{'l', dbusMethodCall, 0, 1}
+	msg = binary.LittleEndian.AppendUint32(msg, uint32(len(body)))
+	msg = binary.LittleEndian.AppendUint32(msg, d.serial)
+	msg = append(msg, 0, 0, 0, 0) // Header fields length
+	start = len(msg)
+
+	msg = dbusField(msg, dbusFieldPath, "o", path)
+	msg = dbusField(msg, dbusFieldInterface, "s", iface)
+	msg = dbusField(msg, dbusFieldMember, "s", member)
+	msg = dbusField(msg, dbusFieldDestination, "s", dest)
+
+	if len(args) > 0 {
+		msg = dbusField(
+			msg,
+			dbusFieldSignature,
+			"g",
+			strings.Repeat("s", len(args)),
+		)
+	}
+
+	binary.LittleEndian.PutUint32(
+		msg[start-4:],
+		uint32(len(msg)-start),
+	)
+
+	//nolint:mnd // Body is 8-byte aligned
+	msg = append(dbusAlign(msg, 8), body...)
+
+	if _, e = d.conn.Write(msg); e != nil {
+		return nil, errors.Newf("failed to call %s: %w", member, e)
+	}
+
+	// Skip any signals, such as NameAcquired
+	for {
+		if r, e = d.read(); e != nil {
+			return nil, errors.Newf(
+				"failed to call %s: %w",
+				member,
+				e,
+			)
+		}
+
+		if r.replySerial != d.serial {
+			continue
+		}
+
+		switch r.msgType {
+		case dbusError:
+			return nil, errors.Newf(
+				"%s failed: %s",
+				member,
+				r.errName,
+			)
+		case dbusMethodReturn:
+			return r, nil
+		}
+	}
+}
+
+func (d *dbus) close() {
+	_ = d.conn.Close()
+}
+
+// read will read the next message from the bus.
+func (d *dbus) read() (*dbusReader, error) {
+	var bodyLen uint32
+	var code byte
+	var e error
+	var fieldsLen uint32
+	var hdr []byte = make([]byte, 16) //nolint:mnd // Fixed header
+	var r *dbusReader = &dbusReader{}
+	var sig string
+
+	if _, e = io.ReadFull(d.r, hdr); e != nil {
+		return nil, e
+	}
+
+	switch hdr[0] {
+	case 'B':
+		r.order = binary.BigEndian
+	case 'l':
+		r.order = binary.LittleEndian
+	default:
+		return nil, errors.Newf("invalid endianness %q", hdr[0])
+	}
+
+	r.msgType = hdr[1]
+	bodyLen = r.order.Uint32(hdr[4:])
+	fieldsLen = r.order.Uint32(hdr[12:])
+
+	if (bodyLen > dbusMaxLen) || (fieldsLen > dbusMaxLen) {
+		return nil, errors.New("message too large")
+	}
+
+	// Offsets are aligned from the start of the message, and the body
+	// is 8-byte aligned
+	r.b = make([]byte, len(hdr)+int(fieldsLen))
+	r.b = dbusAlign(r.b, 8) //nolint:mnd // Body align
+	r.b = append(r.b, make([]byte, bodyLen)...)
+	copy(r.b, hdr)
+
+	if _, e = io.ReadFull(d.r, r.b[len(hdr):]); e != nil {
+		return nil, e
+	}
+
+	for r.off = len(hdr); r.off < len(hdr)+int(fieldsLen); {
+		r.align(8) //nolint:mnd // Header fields are structs
+
+		code = r.byte()
+
+		switch sig = r.signature(); sig {
+		case "g":
+			if code == dbusFieldSignature {
+				r.bodySig = r.signature()
+			} else {
+				r.signature()
+			}
+		case "o", "s":
+			if code == dbusFieldErrorName {
+				r.errName = r.string()
+			} else {
+				r.string()
+			}
+		case "u":
+			if code == dbusFieldReplySerial {
+				r.replySerial = r.uint32()
+			} else {
+				r.uint32()
+			}
+		default:
+			return nil, errors.Newf(
+				"unsupported header field %q",
+				sig,
+			)
+		}
+
+		if r.e != nil {
+			return nil, r.e
+		}
+	}
+
+	r.off = len(r.b) - int(bodyLen)
+
+	return r, nil
+}
+
+// dbusReader is a cursor over a D-Bus message, used to unmarshal
+// the body of a reply.
+type dbusReader struct {
+	b           []byte
+	bodySig     string
+	e           error
+	errName     string
+	msgType     byte
+	off         int
+	order       binary.ByteOrder
+	replySerial uint32
+}
+
+// align will skip any padding up to the next multiple of n.
+func (r *dbusReader) align(n int) {
+	if pad := (n - r.off%n) % n; r.off+pad <= len(r.b) {
+		r.off += pad
+	} else {
+		r.fail()
+	}
+}
+
+// byte will read a single byte.
+func (r *dbusReader) byte() byte {
+	var out byte
+
+	if r.off >= len(r.b) {
+		r.fail()
+		return 0
+	}
+
+	out = r.b[r.off]
+	r.off++
+
+	return out
+}
+
+// done will return whether or not the cursor has reached the
+// specified offset, or an error has occurred.
+func (r *dbusReader) done(end int) bool {
+	return (r.e != nil) || (r.off >= end)
+}
+
+func (r *dbusReader) fail() {
+	if r.e == nil {
+		r.e = errors.New("message truncated")
+	}
+
+	r.off = len(r.b)
+}
+
+// signature will read a signature, which has a single byte length.
+func (r *dbusReader) signature() string {
+	var n int = int(r.byte())
+	var out string
+
+	if r.off+n+1 > len(r.b) {
+		r.fail()
+		return ""
+	}
+
+	out = string(r.b[r.off : r.off+n])
+	r.off += n + 1
+
+	return out
+}
+
+// string will read a string or object path.
+func (r *dbusReader) string() string {
+	var n int = int(r.uint32())
+	var out string
+
+	if (r.e != nil) || (r.off+n+1 > len(r.b)) {
+		r.fail()
+		return ""
+	}
+
+	out = string(r.b[r.off : r.off+n])
+	r.off += n + 1
+
+	return out
+}
+
+// uint32 will read an aligned uint32.
+func (r *dbusReader) uint32() uint32 {
+	var out uint32
+
+	r.align(4) //nolint:mnd // u32
+
+	if r.off+4 > len(r.b) {
+		r.fail()
+		return 0
+	}
+
+	out = r.order.Uint32(r.b[r.off:])
+	r.off += 4
+
+	return out
+}
+
+// dbusAlign will pad the provided message to the next multiple of n.
+func dbusAlign(b []byte, n int) []byte {
+	for len(b)%n != 0 {
+		b = append(b, 0)
+	}
+
+	return b
+}
+
+// dbusField will append a header field with the provided code,
+// signature, and string value.
+func dbusField(b []byte, code byte, sig string, val string) []byte {
+	//nolint:mnd // Header fields are structs
+	b = append(dbusAlign(b, 8), code)
+	b = append(b, 1)
+	b = append(b, sig...)
+	b = append(b, 0)
+
+	if sig == "g" {
+		b = append(b, byte(len(val)))
+		b = append(b, val...)
+
+		return append(b, 0)
+	}
+
+	return dbusString(dbusAlign(b, 4), val) //nolint:mnd // u32
+}
+
+// dbusPath will return the socket path of the first unix address in
+// the provided D-Bus server address.
+func dbusPath(addr string) (string, error) {
+	var kv []string
+
+	for _, a := range strings.Split(addr, ";") {
+		if !strings.HasPrefix(a, "unix:") {
+			continue
+		}
+
+		for _, opt := range strings.Split(a[len("unix:"):], ",") {
+			//nolint:mnd // Key and value
+			if kv = strings.SplitN(opt, "=", 2); len(kv) != 2 {
+				continue
+			}
+
+			switch kv[0] {
+			case "abstract":
+				return "@" + kv[1], nil
+			case "path":
+				return kv[1], nil
+			}
+		}
+	}
+
+	return "", errors.Newf("unsupported D-Bus address %s", addr)
+}
+
+// dbusString will append a string, which must already be aligned.
+func dbusString(b []byte, val string) []byte {
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(val)))
+	b = append(b, val...)
+
+	return append(b, 0)
+}
@@ -0,0 +1,97 @@
+//go:build !darwin && !windows
+
+package sysinfo
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func (s *SysInfo) desktop() {
+	var de string = os.Getenv("XDG_CURRENT_DESKTOP")
+
+	// May be a list such as "ubuntu:GNOME", the last is most generic
+	if tmp := strings.Split(de, ":"); len(tmp) > 1 {
+		de = tmp[len(tmp)-1]
+	}
+
+	if de == "" {
+		de = filepath.Base(os.Getenv("DESKTOP_SESSION"))
+		if de == "." {
+			de = ""
+		}
+	}
+
+	de = strings.TrimPrefix(de, "X-")
+
+	switch strings.ToLower(de) {
+	case "kde", "plasma", "plasmawayland":
+		de = "KDE Plasma"
+	case "gnome", "gnome-xorg", "gnome-wayland":
+		de = "GNOME"
+	case "xfce", "xfce4":
+		de = "Xfce"
+	}
+
+	s.DE = de
+}
+
+func (s *SysInfo) session() {
+	var session string = os.Getenv("XDG_SESSION_TYPE")
+
+	switch {
+	case session != "":
+	case os.Getenv("WAYLAND_DISPLAY") != "":
+		session = "wayland"
+	case os.Getenv("DISPLAY") != "":
+		session = "x11"
+	}
+
+	switch strings.ToLower(session) {
+	case "mir":
+		s.Session = "Mir"
+	case "tty":
+		s.Session = "TTY"
+	case "wayland":
+		s.Session = "Wayland"
+	case "x11":
+		s.Session = "X11"
+	default:
+		s.Session = session
+	}
+}
+
+func (s *SysInfo) windowManager() {
+	var comm string
+	var found string
+	var uid int = os.Getuid()
+
+	s.WM = ""
+
+	for _, pid := range pids() {
+		comm = procComm(pid)
+
+		// xmonad is compiled as xmonad-<arch>-<os> and comm is
+		// truncated to 15 chars (e.g. xmonad-x86_64-l)
+		wm, ok := windowManagers[comm]
+		if !ok {
+			comm = reWMArch.ReplaceAllString(comm, "")
+			if wm, ok = windowManagers[comm]; !ok {
+				continue
+			}
+		}
+
+		// Prefer the current user's session, if any
+		if procUID(pid) == uid {
+			s.WM = wm
+			return
+		}
+
+		if found == "" {
+			found = wm
+		}
+	}
+
+	s.WM = found
+}
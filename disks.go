@@ -0,0 +1,62 @@
+package sysinfo
+
+import (
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Disk is a struct containing details about a block device.
+type Disk struct {
+	Model      string   `json:"model,omitempty"`
+	Name       string   `json:"name"`
+	PartTable  string   `json:"partition_table,omitempty"`
+	Partitions int      `json:"partitions"`
+	Removable  bool     `json:"removable"`
+	Size       uint64   `json:"size"`
+	Type       string   `json:"type"`
+	Warnings   []string `json:"warnings,omitempty"`
+}
+
+// String will return a string representation of the Disk.
+func (d Disk) String() string {
+	var out []string = []string{d.Name}
+
+	if d.Model != "" {
+		out[0] += " (" + d.Model + ")"
+	}
+
+	out[0] += " " + formatBytes(d.Size) + " " + d.Type
+
+	if d.PartTable != "" {
+		out = append(out, strings.ToUpper(d.PartTable))
+	}
+
+	switch d.Partitions {
+	case 0:
+	case 1:
+		out = append(out, "1 partition")
+	default:
+		out = append(out, strconv.Itoa(d.Partitions)+" partitions")
+	}
+
+	if d.Removable {
+		out = append(out, "removable")
+	}
+
+	out = append(out, d.Warnings...)
+
+	return strings.Join(out, ", ")
+}
+
+// keepDisk will return whether or not the specified block device
+// should be reported, based on ExcludeDisks.
+func keepDisk(name string) bool {
+	for _, glob := range ExcludeDisks {
+		if ok, _ := path.Match(glob, name); ok {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,263 @@
+//go:build linux
+
+package sysinfo
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/mjwhitta/pathname"
+)
+
+func (s *SysInfo) disks() {
+	var dir string
+	var e error
+	var entries []os.DirEntry
+	var removable bool
+	var sectors uint64
+
+	s.Disks = nil
+
+	if entries, e = os.ReadDir("/sys/block"); e != nil {
+		return
+	}
+
+	for _, entry := range entries {
+		if !keepDisk(entry.Name()) {
+			continue
+		}
+
+		dir = filepath.Join("/sys/block", entry.Name())
+
+		// Sizes are always in 512-byte sectors
+		sectors, e = strconv.ParseUint(
+			readTrim(filepath.Join(dir, "size")),
+			10,
+			64,
+		)
+		if (e != nil) || (sectors == 0) {
+			// No media, such as an empty card reader
+			continue
+		}
+
+		removable = readTrim(filepath.Join(dir, "removable")) == "1"
+
+		s.Disks = append(
+			s.Disks,
+			Disk{
+				Model:      diskModel(dir),
+				Name:       entry.Name(),
+				PartTable:  partTable(dir, entry.Name()),
+				Partitions: partitions(dir),
+				Removable:  removable,
+				Size:       sectors * 512, //nolint:mnd // Sector size
+				Type:       diskType(dir, entry.Name()),
+				Warnings:   diskWarnings(dir),
+			},
+		)
+	}
+}
+
+// diskModel will return the model of the block device in the
+// provided sysfs directory, falling back to the driver.
+func diskModel(dir string) string {
+	var driver string
+	var model string
+	var vendor string
+
+	model = readTrim(filepath.Join(dir, "device", "model"))
+	vendor = readTrim(filepath.Join(dir, "device", "vendor"))
+
+	// Vendor is typically ATA or a PCI ID, so only keep real names
+	switch {
+	case model == "":
+	case (vendor == "") || (vendor == "ATA"):
+	case strings.HasPrefix(vendor, "0x"):
+	case strings.HasPrefix(model, vendor):
+	default:
+		model = vendor + " " + model
+	}
+
+	if model != "" {
+		return reWhiteSpace.ReplaceAllString(model, " ")
+	}
+
+	driver, _ = filepath.EvalSymlinks(
+		filepath.Join(dir, "device", "driver"),
+	)
+
+	switch filepath.Base(driver) {
+	case "virtio_blk":
+		return "VirtIO"
+	case "xen_blkfront", "vbd":
+		return "Xen"
+	}
+
+	return ""
+}
+
+// diskType will return whether the block device in the provided
+// sysfs directory is an HDD, SSD, NVMe, or virtual device.
+func diskType(dir string, name string) string {
+	var dev string = filepath.Join(dir, "device")
+
+	if ok, _ := pathname.DoesExist(dev); !ok {
+		// Such as zram, device mapper, or md
+		return "virtual"
+	}
+
+	// Such as virtio and Xen, which report as rotational
+	if strings.HasPrefix(name, "vd") ||
+		strings.HasPrefix(name, "xvd") {
+		return "virtual"
+	}
+
+	if strings.HasPrefix(name, "nvme") {
+		return "NVMe"
+	}
+
+	if readTrim(filepath.Join(dir, "queue", "rotational")) == "1" {
+		return "HDD"
+	}
+
+	return "SSD"
+}
+
+// diskWarnings will return any health hints available from sysfs
+// for the block device in the provided sysfs directory, such as the
+// device state or a temperature alarm.
+func diskWarnings(dir string) []string {
+	var alarms []string
+	var dev string = filepath.Join(dir, "device")
+	var out []string
+	var state string
+
+	// NVMe controller or SCSI device
+	switch state = readTrim(filepath.Join(dev, "state")); state {
+	case "", "live", "running":
+	default:
+		out = append(out, "state "+state)
+	}
+
+	// NVMe and drivetemp sensors
+	for _, glob := range []string{
+		filepath.Join(dev, "hwmon*", "temp*_alarm"),
+		filepath.Join(dev, "hwmon", "hwmon*", "temp*_alarm"),
+	} {
+		alarms, _ = filepath.Glob(glob)
+		for _, fn := range alarms {
+			if readTrim(fn) == "1" {
+				return append(out, "temperature alarm")
+			}
+		}
+	}
+
+	return out
+}
+
+// mbrTable will return the partition table type described by the
+// provided MBR partition entries. A protective entry means GPT, and
+// any other valid entry means DOS. Boot sectors of filesystems, which
+// also end in 0x55AA, have no valid entries.
+//
+//nolint:mnd // MBR partition entry layout
+func mbrTable(entries []byte) string {
+	var entry []byte
+	var found bool
+
+	for i := 0; i+16 <= len(entries); i += 16 {
+		entry = entries[i : i+16]
+
+		// Status is either inactive or bootable
+		if (entry[0] != 0x00) && (entry[0] != 0x80) {
+			return ""
+		}
+
+		switch entry[4] {
+		case 0x00:
+		case 0xee:
+			return "gpt"
+		default:
+			found = true
+		}
+	}
+
+	if found {
+		return "dos"
+	}
+
+	return ""
+}
+
+// partitions will return the number of partitions on the block
+// device in the provided sysfs directory.
+func partitions(dir string) int {
+	var entries []os.DirEntry
+	var fn string
+	var n int
+
+	entries, _ = os.ReadDir(dir)
+	for _, entry := range entries {
+		fn = filepath.Join(dir, entry.Name(), "partition")
+		if ok, _ := pathname.DoesExist(fn); ok {
+			n++
+		}
+	}
+
+	return n
+}
+
+// partTable will return the partition table type, using the udev
+// database if available, otherwise the disk label itself.
+func partTable(dir string, name string) string {
+	var b []byte = make([]byte, 4096+8) //nolint:mnd // LBA 1 at 4K
+	var e error
+	var f *os.File
+	var n int
+	var udev string = "/run/udev/data/b" + readTrim(
+		filepath.Join(dir, "dev"),
+	)
+
+	for _, line := range strings.Split(readTrim(udev), "\n") {
+		if tmp, ok := strings.CutPrefix(
+			line,
+			"E:ID_PART_TABLE_TYPE=",
+		); ok {
+			return tmp
+		}
+	}
+
+	// Requires read access to the device
+	if f, e = os.Open(filepath.Join("/dev", name)); e != nil {
+		return ""
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	// Disks smaller than 4K are only checked for an MBR
+	if n, _ = io.ReadFull(f, b); n < 512 {
+		return ""
+	}
+
+	b = b[:n]
+
+	// GPT header is at LBA 1, for 512-byte or 4K sectors
+	for _, offset := range []int{512, 4096} {
+		if (len(b) >= offset+8) &&
+			bytes.Equal(b[offset:offset+8], []byte("EFI PART")) {
+			return "gpt"
+		}
+	}
+
+	//nolint:mnd // MBR boot signature
+	if (b[510] != 0x55) || (b[511] != 0xaa) {
+		return ""
+	}
+
+	return mbrTable(b[446:510]) //nolint:mnd // 4 partition entries
+}
@@ -0,0 +1,84 @@
+//go:build linux
+
+package sysinfo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDiskType(t *testing.T) {
+	var dir string = t.TempDir()
+	var e error
+	var tests map[string]string = map[string]string{
+		"nvme0n1": "NVMe",
+		"sda":     "HDD",
+		"vda":     "virtual",
+		"xvda":    "virtual",
+	}
+
+	for _, sub := range []string{"device", "queue"} {
+		if e = os.MkdirAll(filepath.Join(dir, sub), 0o700); e != nil {
+			t.Fatal(e)
+		}
+	}
+
+	e = os.WriteFile(
+		filepath.Join(dir, "queue", "rotational"),
+		[]byte("1\n"),
+		0o600,
+	)
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	for name, expected := range tests {
+		if typ := diskType(dir, name); typ != expected {
+			t.Errorf("%s: got %s, want %s", name, typ, expected)
+		}
+	}
+
+	// No device, such as zram or device mapper
+	if typ := diskType(t.TempDir(), "dm-0"); typ != "virtual" {
+		t.Errorf("dm-0: got %s, want virtual", typ)
+	}
+}
+
+func TestMBRTable(t *testing.T) {
+	var bootCode []byte = make([]byte, 64)
+	var dos []byte = make([]byte, 64)
+	var empty []byte = make([]byte, 64)
+	var gpt []byte = make([]byte, 64)
+	var tests map[string][]byte = map[string][]byte{
+		"":    empty,
+		"dos": dos,
+		"gpt": gpt,
+	}
+
+	// Bootable Linux partition, then an extended partition
+	dos[0], dos[4], dos[16+4] = 0x80, 0x83, 0x05
+
+	// Protective MBR
+	gpt[4] = 0xee
+
+	// Filesystem boot code, rather than partition entries
+	for i := range bootCode {
+		bootCode[i] = byte(i + 1)
+	}
+
+	for expected, entries := range tests {
+		if table := mbrTable(entries); table != expected {
+			t.Errorf("got %q, want %q", table, expected)
+		}
+	}
+
+	if table := mbrTable(bootCode); table != "" {
+		t.Errorf("boot code: got %q, want \"\"", table)
+	}
+
+	// Truncated entries are ignored
+	if table := mbrTable(gpt[:8]); table != "" {
+		t.Errorf("truncated: got %q, want \"\"", table)
+	}
+}
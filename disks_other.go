@@ -0,0 +1,8 @@
+//go:build !linux
+
+package sysinfo
+
+// disks is not supported on this platform.
+func (s *SysInfo) disks() {
+	s.Disks = nil
+}
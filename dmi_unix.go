@@ -0,0 +1,108 @@
+//go:build !darwin && !windows
+
+package sysinfo
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func (s *SysInfo) firmware() {
+	var date string = dmi("bios_date")
+
+	s.Firmware = joinNonEmpty(
+		" ",
+		dmi("bios_vendor"),
+		dmi("bios_version"),
+	)
+
+	if (s.Firmware != "") && (date != "") {
+		s.Firmware += " (" + date + ")"
+	}
+}
+
+func (s *SysInfo) model() {
+	var chassis string = chassisTypes[dmi("chassis_type")]
+	var product string = dmi("product_name")
+	var vendor string = dmi("sys_vendor")
+	var version string = dmi("product_version")
+
+	s.Model = "unknown"
+
+	if ShowSerials {
+		s.Serial = dmi("product_serial")
+		if s.Serial == "" {
+			s.Serial = dmi("board_serial")
+		}
+
+		if s.Serial == "" {
+			s.Serial = readTrim("/proc/device-tree/serial-number")
+		}
+	}
+
+	// Fall back to the board when the product is not populated
+	if product == "" {
+		product = dmi("board_name")
+		vendor = dmi("board_vendor")
+		version = ""
+	}
+
+	if strings.HasPrefix(product, vendor) {
+		vendor = ""
+	}
+
+	if strings.Contains(product, version) {
+		version = ""
+	}
+
+	if tmp := joinNonEmpty(" ", vendor, product, version); tmp != "" {
+		s.Model = tmp
+		if chassis != "" {
+			s.Model += " (" + chassis + ")"
+		}
+
+		return
+	}
+
+	// ARM boards and friends usually have a device tree instead
+	for _, fn := range []string{
+		"/proc/device-tree/model",
+		"/sys/firmware/devicetree/base/model",
+	} {
+		if tmp := readTrim(fn); tmp != "" {
+			s.Model = tmp
+			return
+		}
+	}
+}
+
+// dmi will return the value of the specified DMI attribute, ignoring
+// common OEM placeholder values.
+func dmi(name string) string {
+	var val string = readTrim(filepath.Join("/sys/class/dmi/id", name))
+
+	switch strings.ToLower(val) {
+	case "0123456789", "default string", "none", "not applicable",
+		"not specified", "o.e.m.", "system manufacturer",
+		"system product name", "system serial number",
+		"system version", "to be filled by o.e.m.",
+		"type1productconfigid":
+		return ""
+	}
+
+	return val
+}
+
+// readTrim will return the contents of the specified file with any
+// surrounding whitespace or NUL bytes removed.
+func readTrim(fn string) string {
+	var b []byte
+	var e error
+
+	if b, e = os.ReadFile(filepath.Clean(fn)); e != nil {
+		return ""
+	}
+
+	return strings.Trim(string(b), " \t\r\n\x00")
+}
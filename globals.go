@@ -1,51 +1,586 @@
 package sysinfo
 
-import "regexp"
+import (
+	"regexp"
+	"time"
+)
 
 // Version is the package version
 const Version string = "1.7.6"
 
+// Supported color depths
+const (
+	colors16   int = 16
+	colors256  int = 256
+	colorsTrue int = 1 << 24
+)
+
+// Route flags, see include/uapi/linux/route.h
+const (
+	rtfUp      uint64 = 0x0001
+	rtfGateway uint64 = 0x0002
+	rtfReject  uint64 = 0x0200
+)
+
+// D-Bus message types and header fields, see the D-Bus specification
+const (
+	dbusMethodCall   byte = 1
+	dbusMethodReturn byte = 2
+	dbusError        byte = 3
+
+	dbusFieldPath        byte = 1
+	dbusFieldInterface   byte = 2
+	dbusFieldMember      byte = 3
+	dbusFieldErrorName   byte = 4
+	dbusFieldReplySerial byte = 5
+	dbusFieldDestination byte = 6
+	dbusFieldSignature   byte = 8
+
+	// Maximum array and body length
+	dbusMaxLen uint32 = 1 << 26
+)
+
+// Generic netlink header size and attribute type mask, see
+// include/uapi/linux/genetlink.h and include/uapi/linux/netlink.h
+const (
+	genlHdrLen  int    = 4
+	nlaTypeMask uint16 = 0x3fff
+)
+
+// Types of utmp records, see utmp(5)
+const (
+	utmpRunLevel    int16 = 1
+	utmpBootTime    int16 = 2
+	utmpUserProcess int16 = 7
+)
+
+// Length of an LVM device mapper UUID, without a layer suffix
+const lvmUUIDLen int = len("LVM-") + 64
+
+// How urgently a value needs attention, see warnColors and critColors
+const (
+	severityOK int = iota
+	severityWarn
+	severityCrit
+)
+
+// Address of the systemd-resolved stub resolver
+const resolvedStub string = "127.0.0.53"
+
+// Number of cells in the gradient and truecolor palettes
+const paletteWidth int = 48
+
 var (
-	reCommaSpace *regexp.Regexp = regexp.MustCompile(
-		`^(,?\s*)+|(,?\s*)+$`,
-	)
-	reCPUBrand *regexp.Regexp = regexp.MustCompile(
+	// BytePrecision is the number of decimal places shown for sizes.
+	BytePrecision int = 1
+
+	// ByteUnits is how sizes are displayed. Valid values are "iec"
+	// (KiB, MiB, GiB), "si" (kB, MB, GB), "auto" (K, M, G, like
+	// df -h), or a fixed unit such as "MiB" or "GB".
+	ByteUnits string = "iec"
+
+	// ExcludeDisks is a list of glob patterns for block devices that
+	// should not be reported.
+	ExcludeDisks []string = []string{"loop*", "ram*"}
+
+	// ExcludeInterfaces is a list of glob patterns for network
+	// interfaces that should not be reported.
+	ExcludeInterfaces []string = []string{"docker*"}
+
+	// Glyphs is the style of the colors field. Valid styles are
+	// "blocks", "bars", and "circles".
+	Glyphs string = "blocks"
+
+	// HideLoopbackPorts will determine whether or not sockets only
+	// listening on a loopback address are hidden from the ports
+	// field.
+	HideLoopbackPorts bool
+
+	// IncludeInterfaces is a list of glob patterns for network
+	// interfaces that should be reported. If empty, all interfaces
+	// are included.
+	IncludeInterfaces []string
+
+	// Labels is a map of field names, or unit words such as "days",
+	// to custom labels. These take precedence over the translations
+	// for the current Language.
+	Labels map[string]string
+
+	// Language is the language used for labels and unit words, such
+	// as "de". If empty, it is determined from LC_ALL, LC_MESSAGES,
+	// or LANG.
+	Language string
+
+	// Palette is the palette shown by the colors field. Valid
+	// palettes are "16", "256", "truecolor", and "gradient".
+	Palette string = "16"
+
+	// PublicIPCacheTTL is how long the public IP is cached on disk. A
+	// value of 0 disables the cache.
+	PublicIPCacheTTL time.Duration = 15 * time.Minute
+
+	// PublicIPSource is where the public IP is retrieved from. It can
+	// be an HTTP(S) URL that returns the IP as plain text, or a DNS
+	// URL such as dns://resolver1.opendns.com/myip.opendns.com or
+	// dns://ns1.google.com/o-o.myaddr.l.google.com?type=TXT.
+	PublicIPSource string = "https://api.ipify.org"
+
+	// PublicIPTimeout is the maximum time spent retrieving the public
+	// IP.
+	PublicIPTimeout time.Duration = 2 * time.Second
+
+	// ShowSerials will determine whether or not hardware serial
+	// numbers are collected. They are sensitive, so they are never
+	// shown in text output, only in JSON.
+	ShowSerials bool
+
+	// SystemBus is the D-Bus system bus address used by the services
+	// field, such as unix:path=/run/dbus/system_bus_socket. If empty,
+	// DBUS_SYSTEM_BUS_ADDRESS or the default socket is used.
+	SystemBus string
+
+	// UptimeStyle is how the uptime field is displayed. Valid styles
+	// are "long" (3 days, 4 hours), "short" (3d 4h), and "iso8601"
+	// (P3DT4H5M6S).
+	UptimeStyle string = "long"
+
+	catalogs map[string]catalog = map[string]catalog{
+		"de": {
+			"boot":             "Start",
+			"day":              "Tag",
+			"days":             "Tage",
+			"deleted lib":      "1 gelöschte Bibliothek genutzt",
+			"deleted libs":     "%d gelöschte Bibliotheken genutzt",
+			"disks":            "Laufwerke",
+			"hour":             "Stunde",
+			"hours":            "Stunden",
+			"kernel installed": "Kernel %s installiert",
+			"min":              "Minute",
+			"mins":             "Minuten",
+			"model":            "Modell",
+			"net":              "Netz",
+			"not required":     "nicht erforderlich",
+			"os":               "BS",
+			"packages":         "Pakete",
+			"public_ip":        "Öffentliche IP",
+			"reboot":           "Neustart",
+			"reboot required":  "Neustart erforderlich",
+			"requested":        "angefordert",
+			"requested by":     "angefordert von %s",
+			"services":         "Dienste",
+			"session":          "Sitzung",
+			"storage":          "Speicher",
+			"unknown":          "unbekannt",
+			"uptime":           "Laufzeit",
+			"user":             "Benutzer",
+			"users":            "Angemeldet",
+			"vulns":            "Lücken",
+			"wifi":             "WLAN",
+		},
+		"en": {
+			"day":              "day",
+			"days":             "days",
+			"deleted lib":      "1 deleted library in use",
+			"deleted libs":     "%d deleted libraries in use",
+			"hour":             "hour",
+			"hours":            "hours",
+			"kernel installed": "kernel %s installed",
+			"min":              "min",
+			"mins":             "mins",
+			"requested by":     "requested by %s",
+			"unknown":          "unknown",
+		},
+		"es": {
+			"boot":             "Arranque",
+			"day":              "día",
+			"days":             "días",
+			"deleted lib":      "1 biblioteca eliminada en uso",
+			"deleted libs":     "%d bibliotecas eliminadas en uso",
+			"disks":            "Discos",
+			"gateway":          "Puerta de enlace",
+			"host":             "Equipo",
+			"hour":             "hora",
+			"hours":            "horas",
+			"kernel installed": "kernel %s instalado",
+			"min":              "minuto",
+			"mins":             "minutos",
+			"model":            "Modelo",
+			"net":              "Red",
+			"not required":     "no requerido",
+			"os":               "SO",
+			"packages":         "Paquetes",
+			"ports":            "Puertos",
+			"public_ip":        "IP pública",
+			"reboot":           "Reinicio",
+			"reboot required":  "reinicio requerido",
+			"requested":        "solicitado",
+			"requested by":     "solicitado por %s",
+			"services":         "Servicios",
+			"session":          "Sesión",
+			"storage":          "Almacenamiento",
+			"unknown":          "desconocido",
+			"uptime":           "Activo",
+			"user":             "Usuario",
+			"users":            "Usuarios",
+			"vulns":            "Fallos",
+		},
+		"fr": {
+			"boot":             "Démarrage",
+			"day":              "jour",
+			"days":             "jours",
+			"deleted lib":      "1 bibliothèque effacée en usage",
+			"deleted libs":     "%d bibliothèques effacées en usage",
+			"disks":            "Disques",
+			"firmware":         "Micrologiciel",
+			"gateway":          "Passerelle",
+			"host":             "Hôte",
+			"hour":             "heure",
+			"hours":            "heures",
+			"kernel":           "Noyau",
+			"kernel installed": "noyau %s installé",
+			"min":              "minute",
+			"mins":             "minutes",
+			"model":            "Modèle",
+			"net":              "Réseau",
+			"not required":     "non requis",
+			"os":               "SE",
+			"packages":         "Paquets",
+			"public_ip":        "IP publique",
+			"reboot":           "Redémarrage",
+			"reboot required":  "redémarrage requis",
+			"requested":        "demandé",
+			"requested by":     "demandé par %s",
+			"services":         "Services",
+			"storage":          "Stockage",
+			"unknown":          "inconnu",
+			"uptime":           "Durée",
+			"user":             "Utilisateur",
+			"users":            "Utilisateurs",
+			"vulns":            "Failles",
+		},
+	}
+	chassisTypes map[string]string = map[string]string{
+		"3":  "Desktop",
+		"4":  "Low Profile Desktop",
+		"5":  "Pizza Box",
+		"6":  "Mini Tower",
+		"7":  "Tower",
+		"8":  "Portable",
+		"9":  "Laptop",
+		"10": "Notebook",
+		"11": "Hand Held",
+		"12": "Docking Station",
+		"13": "All in One",
+		"14": "Sub Notebook",
+		"15": "Space-saving",
+		"16": "Lunch Box",
+		"17": "Main Server Chassis",
+		"18": "Expansion Chassis",
+		"19": "SubChassis",
+		"20": "Bus Expansion Chassis",
+		"21": "Peripheral Chassis",
+		"22": "RAID Chassis",
+		"23": "Rack Mount Chassis",
+		"24": "Sealed-case PC",
+		"25": "Multi-system Chassis",
+		"26": "Compact PCI",
+		"27": "Advanced TCA",
+		"28": "Blade",
+		"29": "Blade Enclosure",
+		"30": "Tablet",
+		"31": "Convertible",
+		"32": "Detachable",
+		"33": "IoT Gateway",
+		"34": "Embedded PC",
+		"35": "Mini PC",
+		"36": "Stick PC",
+	}
+	containers [][]string = [][]string{
+		{"kubepods", "Kubernetes pod"},
+		{"libpod", "Podman container"},
+		{"podman", "Podman container"},
+		{"docker", "Docker container"},
+		{"lxc", "LXC container"},
+		{"systemd-nspawn", "systemd-nspawn container"},
+		{"containerd", "containerd container"},
+		{"oci", "OCI container"},
+	}
+	hypervisors [][]string = [][]string{
+		{"amazon ec2", "Amazon EC2"},
+		{"apple virtualization", "Apple Virtualization"},
+		{"bhyve", "bhyve"},
+		{"bochs", "Bochs"},
+		{"google compute engine", "Google Compute Engine"},
+		{"innotek", "VirtualBox"},
+		{"kvm", "KVM"},
+		{"openstack", "OpenStack"},
+		{"parallels", "Parallels"},
+		{"qemu", "QEMU"},
+		{"virtual machine", "Hyper-V"},
+		{"virtualbox", "VirtualBox"},
+		{"vmware", "VMware"},
+		{"xen", "Xen"},
+	}
+	initSystems map[string]string = map[string]string{
+		"busybox":     "BusyBox init",
+		"catatonit":   "catatonit",
+		"docker-init": "tini",
+		"dumb-init":   "dumb-init",
+		"openrc-init": "OpenRC",
+		"runit":       "runit",
+		"runit-init":  "runit",
+		"s6-svscan":   "s6",
+		"systemd":     "systemd",
+		"tini":        "tini",
+	}
+	notableCaps map[uint]string = map[uint]string{
+		1:  "dac_override",
+		2:  "dac_read_search",
+		6:  "setgid",
+		7:  "setuid",
+		10: "net_bind_service",
+		12: "net_admin",
+		13: "net_raw",
+		16: "sys_module",
+		17: "sys_rawio",
+		19: "sys_ptrace",
+		21: "sys_admin",
+		38: "perfmon",
+		39: "bpf",
+	}
+	pciDevices string = "/sys/bus/pci/devices"
+	// Display controller subclasses, see pci.ids
+	pciDisplayClasses map[string]string = map[string]string{
+		"00": "VGA compatible controller",
+		"01": "XGA compatible controller",
+		"02": "3D controller",
+		"80": "Display controller",
+	}
+	pciIDs []string = []string{
+		"/usr/share/hwdata/pci.ids",
+		"/usr/share/misc/pci.ids",
+		"/usr/share/pci.ids",
+	}
+	// Common display adapter vendors, for when pci.ids is missing
+	pciVendors map[string]string = map[string]string{
+		"1002": "Advanced Micro Devices, Inc. [AMD/ATI]",
+		"102b": "Matrox Electronics Systems Ltd.",
+		"10de": "NVIDIA Corporation",
+		"1234": "QEMU",
+		"1414": "Microsoft Corporation",
+		"15ad": "VMware",
+		"1a03": "ASPEED Technology, Inc.",
+		"1af4": "Red Hat, Inc.",
+		"1b36": "Red Hat, Inc.",
+		"5143": "Qualcomm Inc.",
+		"80ee": "InnoTek Systemberatung GmbH",
+		"8086": "Intel Corporation",
+	}
+	procNetWireless string         = "/proc/net/wireless"
+	reBootTime      *regexp.Regexp = regexp.MustCompile(`sec = (\d+)`)
+	reCPUBrand      *regexp.Regexp = regexp.MustCompile(
 		`\((R|TM)\)| (@|CPU)`,
 	)
-	reHrMin *regexp.Regexp = regexp.MustCompile(
-		`0?(\d+):0?(\d+)`,
+	reExtendedColor *regexp.Regexp = regexp.MustCompile(
+		`^(on)?(#?[0-9a-f]{6}([0-9a-f]{2})?|color\d+)$`,
+	)
+	reFlavor     *regexp.Regexp = regexp.MustCompile(`[A-Za-z]+`)
+	reHypervisor *regexp.Regexp = regexp.MustCompile(
+		`(?m)^flags\s+:.*\bhypervisor\b`,
+	)
+	reMicrocode *regexp.Regexp = regexp.MustCompile(
+		`(?m)^microcode\s+:\s+(\S+)`,
+	)
+	reMDArray *regexp.Regexp = regexp.MustCompile(
+		`^(md\S+) : (\S+) ?(.*)$`,
+	)
+	reMDProgress *regexp.Regexp = regexp.MustCompile(
+		`(check|recovery|repair|reshape|resync)\s*=\s*(\S+)`,
+	)
+	reMDStatus *regexp.Regexp = regexp.MustCompile(
+		`\[(\d+)/(\d+)\]`,
 	)
 	reModelName *regexp.Regexp = regexp.MustCompile(
 		`(cpu model|model name)\s+:\s+(.+)`,
 	)
-	reOneHrMin *regexp.Regexp = regexp.MustCompile(
-		`(^|,\s+)1\s+(hour|min)s`,
-	)
 	rePrettyName *regexp.Regexp = regexp.MustCompile(
 		`PRETTY_NAME="(.+)"`,
 	)
 	reRAM *regexp.Regexp = regexp.MustCompile(
 		`Mem:\s+(\d+)\s+(\d+)`,
 	)
-	reUptimeEnds *regexp.Regexp = regexp.MustCompile(
-		`^.*up\s+|,\s+\d+\s+user.+$`,
+	reVersion *regexp.Regexp = regexp.MustCompile(
+		`\d+\.\d+[\w.]*`,
 	)
-	reWhiteSpace  *regexp.Regexp = regexp.MustCompile(`\s+`)
-	reZeroHrsMins *regexp.Regexp = regexp.MustCompile(
-		`(^|,\s+)0\s+(hour|min)s`,
+	reVersionParts *regexp.Regexp = regexp.MustCompile(`\d+|\D+`)
+	reWhiteSpace   *regexp.Regexp = regexp.MustCompile(`\s+`)
+	reWMArch       *regexp.Regexp = regexp.MustCompile(
+		`-(aarch64|arm|i386|x86_64)(-.*)?$`,
 	)
+	unitsIEC []string = []string{
+		"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB",
+	}
+	unitsSI []string = []string{
+		"B", "kB", "MB", "GB", "TB", "PB", "EB",
+	}
+	unitsShort []string = []string{
+		"B", "K", "M", "G", "T", "P", "E",
+	}
+	windowManagers map[string]string = map[string]string{
+		"awesome":       "awesome",
+		"bspwm":         "bspwm",
+		"budgie-wm":     "Budgie",
+		"cage":          "Cage",
+		"cinnamon":      "Muffin",
+		"compiz":        "Compiz",
+		"cwm":           "cwm",
+		"dwl":           "dwl",
+		"dwm":           "dwm",
+		"enlightenment": "Enlightenment",
+		"fluxbox":       "Fluxbox",
+		"fvwm":          "FVWM",
+		"fvwm3":         "FVWM3",
+		"gala":          "Gala",
+		"gamescope":     "Gamescope",
+		"gnome-shell":   "Mutter",
+		"herbstluftwm":  "herbstluftwm",
+		"hyprland":      "Hyprland",
+		"Hyprland":      "Hyprland",
+		"i3":            "i3",
+		"icewm":         "IceWM",
+		"jwm":           "JWM",
+		"kwin":          "KWin",
+		"kwin_wayland":  "KWin",
+		"kwin_x11":      "KWin",
+		"labwc":         "labwc",
+		"marco":         "Marco",
+		"metacity":      "Metacity",
+		"muffin":        "Muffin",
+		"mutter":        "Mutter",
+		"niri":          "niri",
+		"openbox":       "Openbox",
+		"qtile":         "Qtile",
+		"river":         "River",
+		"spectrwm":      "spectrwm",
+		"sway":          "Sway",
+		"wayfire":       "Wayfire",
+		"weston":        "Weston",
+		"wmaker":        "Window Maker",
+		"xfwm4":         "Xfwm4",
+		"xmonad":        "xmonad",
+	}
+	shells map[string]bool = map[string]bool{
+		"ash":    true,
+		"bash":   true,
+		"csh":    true,
+		"dash":   true,
+		"elvish": true,
+		"fish":   true,
+		"ksh":    true,
+		"mksh":   true,
+		"nu":     true,
+		"oksh":   true,
+		"pwsh":   true,
+		"sh":     true,
+		"tcsh":   true,
+		"xonsh":  true,
+		"yash":   true,
+		"zsh":    true,
+	}
+	sysClassNet string = "/sys/class/net"
+	// Bit order of /proc/sys/kernel/tainted
+	taintFlags [][]string = [][]string{
+		{"P", "proprietary module"},
+		{"F", "force loaded"},
+		{"S", "out of spec"},
+		{"R", "force unloaded"},
+		{"M", "machine check"},
+		{"B", "bad page"},
+		{"U", "userspace"},
+		{"D", "died recently"},
+		{"A", "ACPI overridden"},
+		{"W", "warning"},
+		{"C", "staging"},
+		{"I", "firmware workaround"},
+		{"O", "out-of-tree module"},
+		{"E", "unsigned module"},
+		{"L", "soft lockup"},
+		{"K", "live patched"},
+		{"X", "auxiliary"},
+		{"T", "randstruct"},
+		{"N", "test"},
+		{"J", "fwctl"},
+	}
+	terminals map[string]string = map[string]string{
+		"alacritty":       "Alacritty",
+		"foot":            "foot",
+		"footclient":      "foot",
+		"ghostty":         "Ghostty",
+		"gnome-terminal":  "GNOME Terminal",
+		"gnome-terminal-": "GNOME Terminal",
+		"guake":           "Guake",
+		"kgx":             "GNOME Console",
+		"kitty":           "kitty",
+		"konsole":         "Konsole",
+		"lxterminal":      "LXTerminal",
+		"mate-terminal":   "MATE Terminal",
+		"mosh-server":     "Mosh",
+		"ptyxis-agent":    "Ptyxis",
+		"qterminal":       "QTerminal",
+		"screen":          "screen",
+		"SCREEN":          "screen",
+		"sshd":            "SSH",
+		"sshd-session":    "SSH",
+		"st":              "st",
+		"terminator":      "Terminator",
+		"terminology":     "Terminology",
+		"tilix":           "Tilix",
+		"tmux":            "tmux",
+		"tmux: server":    "tmux",
+		"urxvt":           "urxvt",
+		"urxvtd":          "urxvt",
+		"wezterm-gui":     "WezTerm",
+		"xfce4-terminal":  "Xfce Terminal",
+		"xterm":           "xterm",
+		"yakuake":         "Yakuake",
+	}
 	titleCase map[string]string = map[string]string{
-		"cpu":    "CPU",
-		"homefs": "HomeFS",
-		"host":   "Host",
-		"ipv4":   "IPv4",
-		"ipv6":   "IPv6",
-		"kernel": "Kernel",
-		"os":     "OS",
-		"ram":    "RAM",
-		"rootfs": "RootFS",
-		"shell":  "Shell",
-		"tty":    "TTY",
-		"uptime": "Uptime",
+		"boot":      "Boot",
+		"cpu":       "CPU",
+		"de":        "DE",
+		"disks":     "Disks",
+		"dns":       "DNS",
+		"firmware":  "Firmware",
+		"gateway":   "Gateway",
+		"gpu":       "GPU",
+		"homefs":    "HomeFS",
+		"host":      "Host",
+		"init":      "Init",
+		"ipv4":      "IPv4",
+		"ipv6":      "IPv6",
+		"kernel":    "Kernel",
+		"model":     "Model",
+		"net":       "Net",
+		"os":        "OS",
+		"packages":  "Packages",
+		"ports":     "Ports",
+		"public_ip": "Public IP",
+		"ram":       "RAM",
+		"reboot":    "Reboot",
+		"rootfs":    "RootFS",
+		"services":  "Services",
+		"session":   "Session",
+		"shell":     "Shell",
+		"storage":   "Storage",
+		"term":      "Term",
+		"terminal":  "Terminal",
+		"tty":       "TTY",
+		"uptime":    "Uptime",
+		"user":      "User",
+		"users":     "Users",
+		"virt":      "Virt",
+		"vulns":     "Vulns",
+		"wifi":      "Wi-Fi",
+		"wm":        "WM",
 	}
 )
@@ -0,0 +1,23 @@
+package sysinfo
+
+// GPU is a struct containing details about a PCI display adapter.
+type GPU struct {
+	Class    string `json:"class"`
+	Device   string `json:"device"`
+	DeviceID string `json:"device_id"`
+	Driver   string `json:"driver,omitempty"`
+	Slot     string `json:"slot"`
+	Vendor   string `json:"vendor"`
+	VendorID string `json:"vendor_id"`
+}
+
+// String will return a string representation of the GPU.
+func (g GPU) String() string {
+	var driver string = g.Driver
+
+	if driver == "" {
+		driver = "no driver"
+	}
+
+	return joinNonEmpty(" ", g.Vendor, g.Device, "("+driver+")")
+}
@@ -0,0 +1,138 @@
+//go:build linux
+
+package sysinfo
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func (s *SysInfo) gpu() {
+	var class string
+	var dir string
+	var driver string
+	var entries []os.DirEntry
+	var g GPU
+
+	s.GPU = nil
+
+	if entries, _ = os.ReadDir(pciDevices); len(entries) == 0 {
+		return
+	}
+
+	for _, entry := range entries {
+		dir = filepath.Join(pciDevices, entry.Name())
+
+		// Display controllers are class 0x03, such as 0x030000
+		class = readTrim(filepath.Join(dir, "class"))
+		if !strings.HasPrefix(class, "0x03") || (len(class) < 6) {
+			continue
+		}
+
+		g = GPU{
+			Class: pciDisplayClasses[class[4:6]],
+			DeviceID: strings.TrimPrefix(
+				readTrim(filepath.Join(dir, "device")),
+				"0x",
+			),
+			Slot: entry.Name(),
+			VendorID: strings.TrimPrefix(
+				readTrim(filepath.Join(dir, "vendor")),
+				"0x",
+			),
+		}
+
+		if g.Class == "" {
+			g.Class = "Display controller"
+		}
+
+		driver, _ = os.Readlink(filepath.Join(dir, "driver"))
+		if driver != "" {
+			g.Driver = filepath.Base(driver)
+		}
+
+		s.GPU = append(s.GPU, g)
+	}
+
+	pciNames(s.GPU)
+}
+
+// pciNames will populate the vendor and device names of the provided
+// GPUs using the first pci.ids database found, falling back to the
+// bundled vendor names.
+func pciNames(gpus []GPU) {
+	var e error
+	var f *os.File
+	var id string
+	var line string
+	var name string
+	var scanner *bufio.Scanner
+	var vendor string
+
+	for i := range gpus {
+		gpus[i].Device = "Device " + gpus[i].DeviceID
+		gpus[i].Vendor = pciVendors[gpus[i].VendorID]
+
+		if gpus[i].Vendor == "" {
+			gpus[i].Vendor = "Vendor " + gpus[i].VendorID
+		}
+	}
+
+	if len(gpus) == 0 {
+		return
+	}
+
+	for _, fn := range pciIDs {
+		if f, e = os.Open(filepath.Clean(fn)); e == nil {
+			break
+		}
+	}
+
+	if e != nil {
+		return
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	// Vendors are unindented and devices are indented once, with the
+	// ID and name separated by two spaces
+	scanner = bufio.NewScanner(f)
+	for scanner.Scan() {
+		line = scanner.Text()
+
+		switch {
+		case (line == "") || strings.HasPrefix(line, "#"):
+		case strings.HasPrefix(line, "C "):
+			// Device classes follow all vendors
+			return
+		case strings.HasPrefix(line, "\t\t"):
+		case strings.HasPrefix(line, "\t"):
+			if vendor == "" {
+				continue
+			}
+
+			id, name, _ = strings.Cut(line[1:], "  ")
+
+			for i := range gpus {
+				if (gpus[i].VendorID == vendor) &&
+					(gpus[i].DeviceID == id) {
+					gpus[i].Device = name
+				}
+			}
+		default:
+			id, name, _ = strings.Cut(line, "  ")
+
+			vendor = ""
+
+			for i := range gpus {
+				if gpus[i].VendorID == id {
+					gpus[i].Vendor = name
+					vendor = id
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,160 @@
+//go:build linux
+
+package sysinfo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testPCIIDs string = `# Fake pci.ids
+8086  Intel Corporation
+	3e92  CoffeeLake-S GT2 [UHD Graphics 630]
+		1028 0869  Vostro 3470
+10de  NVIDIA Corporation
+	1b80  GP104 [GeForce GTX 1080]
+C 03  Display controller
+	00  VGA compatible controller
+`
+
+// fakePCI will create a fake PCI sysfs tree in a temporary directory
+// and point pciDevices and pciIDs at it.
+func fakePCI(t *testing.T, withIDs bool) {
+	t.Helper()
+
+	var devices [][]string = [][]string{
+		// Slot, class, vendor, device, driver
+		{"0000:00:02.0", "0x030000", "0x8086", "0x3e92", "i915"},
+		{"0000:00:1f.6", "0x020000", "0x8086", "0x15bc", "e1000e"},
+		{"0000:01:00.0", "0x030200", "0x10de", "0xffff", ""},
+		{"0000:02:00.0", "0x038000", "0xfffe", "0x0001", ""},
+	}
+	var dir string
+	var e error
+	var files map[string]string
+	var ids []string
+	var tmp string = t.TempDir()
+
+	for _, dev := range devices {
+		dir = filepath.Join(tmp, "devices", dev[0])
+		files = map[string]string{
+			"class":  dev[1] + "\n",
+			"device": dev[3] + "\n",
+			"vendor": dev[2] + "\n",
+		}
+
+		if e = os.MkdirAll(dir, 0o700); e != nil {
+			t.Fatal(e)
+		}
+
+		for name, val := range files {
+			e = os.WriteFile(
+				filepath.Join(dir, name),
+				[]byte(val),
+				0o600,
+			)
+			if e != nil {
+				t.Fatal(e)
+			}
+		}
+
+		if dev[4] == "" {
+			continue
+		}
+
+		e = os.Symlink(
+			filepath.Join(tmp, "drivers", dev[4]),
+			filepath.Join(dir, "driver"),
+		)
+		if e != nil {
+			t.Fatal(e)
+		}
+	}
+
+	e = os.WriteFile(
+		filepath.Join(tmp, "pci.ids"),
+		[]byte(testPCIIDs),
+		0o600,
+	)
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	ids = []string{filepath.Join(tmp, "missing.ids")}
+
+	if withIDs {
+		ids = append(ids, filepath.Join(tmp, "pci.ids"))
+	}
+
+	swap(t, &pciDevices, filepath.Join(tmp, "devices"))
+	swap(t, &pciIDs, ids)
+}
+
+func TestGPU(t *testing.T) {
+	var expected []GPU = []GPU{
+		{
+			Class:    "VGA compatible controller",
+			Device:   "CoffeeLake-S GT2 [UHD Graphics 630]",
+			DeviceID: "3e92",
+			Driver:   "i915",
+			Slot:     "0000:00:02.0",
+			Vendor:   "Intel Corporation",
+			VendorID: "8086",
+		},
+		{
+			Class:    "3D controller",
+			Device:   "Device ffff",
+			DeviceID: "ffff",
+			Slot:     "0000:01:00.0",
+			Vendor:   "NVIDIA Corporation",
+			VendorID: "10de",
+		},
+		{
+			Class:    "Display controller",
+			Device:   "Device 0001",
+			DeviceID: "0001",
+			Slot:     "0000:02:00.0",
+			Vendor:   "Vendor fffe",
+			VendorID: "fffe",
+		},
+	}
+	var s *SysInfo = &SysInfo{}
+
+	fakePCI(t, true)
+	s.gpu()
+
+	if len(s.GPU) != len(expected) {
+		t.Fatalf("got %d GPUs, want %d", len(s.GPU), len(expected))
+	}
+
+	for i := range expected {
+		if s.GPU[i] != expected[i] {
+			t.Errorf("got %+v, want %+v", s.GPU[i], expected[i])
+		}
+	}
+}
+
+func TestGPUNoPCIIDs(t *testing.T) {
+	var s *SysInfo = &SysInfo{}
+
+	fakePCI(t, false)
+	s.gpu()
+
+	if len(s.GPU) == 0 {
+		t.Fatal("got no GPUs")
+	}
+
+	// Falls back to the bundled vendor names
+	if s.GPU[0].Vendor != "Intel Corporation" {
+		t.Errorf("got vendor %q", s.GPU[0].Vendor)
+	}
+
+	if s.GPU[0].Device != "Device 3e92" {
+		t.Errorf("got device %q", s.GPU[0].Device)
+	}
+
+	if s.GPU[0].String() != "Intel Corporation Device 3e92 (i915)" {
+		t.Errorf("got %q", s.GPU[0].String())
+	}
+}
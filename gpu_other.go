@@ -0,0 +1,8 @@
+//go:build !linux
+
+package sysinfo
+
+// gpu is not supported on this platform.
+func (s *SysInfo) gpu() {
+	s.GPU = nil
+}
@@ -0,0 +1,38 @@
+//go:build !darwin && !windows
+
+package sysinfo
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/mjwhitta/pathname"
+)
+
+func (s *SysInfo) initSystem() {
+	var comm string = readTrim("/proc/1/comm")
+	var exe string
+
+	// OpenRC typically runs under sysvinit or busybox
+	if ok, _ := pathname.DoesExist("/run/openrc"); ok {
+		s.Init = "OpenRC"
+		return
+	}
+
+	// Only readable with sufficient privileges, but resolves
+	// symlinks such as /sbin/init
+	if exe, _ = os.Readlink("/proc/1/exe"); exe != "" {
+		exe = filepath.Base(exe)
+	}
+
+	for _, name := range []string{exe, comm} {
+		if tmp, ok := initSystems[name]; ok {
+			s.Init = tmp
+			return
+		}
+	}
+
+	if s.Init = comm; s.Init == "" {
+		s.Init = "unknown"
+	}
+}
@@ -0,0 +1,45 @@
+package sysinfo
+
+import (
+	"strconv"
+	"strings"
+)
+
+// KernelDetail is a struct containing extended kernel information.
+type KernelDetail struct {
+	Arch    string   `json:"arch,omitempty"`
+	Build   string   `json:"build,omitempty"`
+	Cmdline string   `json:"cmdline,omitempty"`
+	LSMs    []string `json:"lsms,omitempty"`
+	Modules int      `json:"modules,omitempty"`
+	Release string   `json:"release"`
+	SELinux string   `json:"selinux,omitempty"`
+	Taint   []string `json:"taint,omitempty"`
+	Tainted uint64   `json:"tainted"`
+}
+
+// String will return a string representation of the KernelDetail,
+// one line per detail. The release and architecture are shown
+// together on the first line, so they are excluded.
+func (k KernelDetail) String() string {
+	var lsm string = strings.Join(k.LSMs, ", ")
+	var out []string = []string{k.Build, k.Cmdline}
+
+	if len(k.Taint) > 0 {
+		out = append(out, "tainted "+strings.Join(k.Taint, ", "))
+	}
+
+	if k.Modules > 0 {
+		out = append(out, strconv.Itoa(k.Modules)+" modules")
+	}
+
+	if k.SELinux != "" {
+		lsm = joinNonEmpty("; ", lsm, "SELinux "+k.SELinux)
+	}
+
+	if lsm != "" {
+		out = append(out, "LSM "+lsm)
+	}
+
+	return joinNonEmpty("\n", out...)
+}
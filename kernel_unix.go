@@ -0,0 +1,76 @@
+//go:build !darwin && !windows
+
+package sysinfo
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+func (s *SysInfo) kernelDetail() {
+	var e error
+	var lsm string
+
+	s.KernelDetail = &KernelDetail{
+		Arch:    readTrim("/proc/sys/kernel/arch"),
+		Cmdline: readTrim("/proc/cmdline"),
+		Modules: countLines("/proc/modules", "", ""),
+		Release: kernelRelease(),
+	}
+
+	if s.KernelDetail.Arch == "" {
+		s.KernelDetail.Arch = s.exec("uname", "-m")
+	}
+
+	// The release is already shown, so just keep the build info
+	s.KernelDetail.Build = readTrim("/proc/version")
+	s.KernelDetail.Build = strings.TrimSpace(
+		strings.TrimPrefix(
+			s.KernelDetail.Build,
+			"Linux version "+s.KernelDetail.Release,
+		),
+	)
+
+	s.KernelDetail.Tainted, e = strconv.ParseUint(
+		readTrim("/proc/sys/kernel/tainted"),
+		10,
+		64,
+	)
+	if e == nil {
+		s.KernelDetail.Taint = taint(s.KernelDetail.Tainted)
+	}
+
+	if lsm = readTrim("/sys/kernel/security/lsm"); lsm != "" {
+		s.KernelDetail.LSMs = strings.Split(lsm, ",")
+	}
+
+	s.KernelDetail.SELinux = selinux()
+}
+
+// selinux will return the SELinux mode, if SELinux is enabled.
+func selinux() string {
+	var dir string = "/sys/fs/selinux"
+
+	switch readTrim(filepath.Join(dir, "enforce")) {
+	case "0":
+		return "permissive"
+	case "1":
+		return "enforcing"
+	default:
+		return ""
+	}
+}
+
+// taint will decode the provided kernel taint mask.
+func taint(mask uint64) []string {
+	var out []string
+
+	for bit, flag := range taintFlags {
+		if (mask & (1 << bit)) != 0 {
+			out = append(out, flag[0]+" ("+flag[1]+")")
+		}
+	}
+
+	return out
+}
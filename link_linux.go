@@ -0,0 +1,89 @@
+//go:build linux
+
+package sysinfo
+
+import (
+	"encoding/binary"
+	"strings"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// linkKind will return the kind of the specified network interface,
+// such as "veth", "macvlan", or "ipvlan", from its rtnetlink link
+// info.
+func linkKind(name string) string {
+	var attrs map[uint16][]byte
+	var b []byte
+	var e error
+	var fd int
+	var kind string
+	var replies [][]byte
+	var size int
+	var tv unix.Timeval = unix.NsecToTimeval(int64(time.Second))
+
+	fd, e = unix.Socket(
+		unix.AF_NETLINK,
+		unix.SOCK_RAW|unix.SOCK_CLOEXEC,
+		unix.NETLINK_ROUTE,
+	)
+	if e != nil {
+		return ""
+	}
+	defer func() {
+		_ = unix.Close(fd)
+	}()
+
+	// Never hang waiting on the kernel
+	e = unix.SetsockoptTimeval(
+		fd,
+		unix.SOL_SOCKET,
+		unix.SO_RCVTIMEO,
+		&tv,
+	)
+	if e != nil {
+		return ""
+	}
+
+	// An empty ifinfomsg, so the link is looked up by name
+	b = make([]byte, unix.SizeofNlMsghdr+unix.SizeofIfInfomsg)
+	b = append(b, nlAttr(unix.IFLA_IFNAME, []byte(name+"\x00"))...)
+
+	binary.NativeEndian.PutUint32(b[0:], uint32(len(b)))
+	binary.NativeEndian.PutUint16(b[4:], unix.RTM_GETLINK)
+	binary.NativeEndian.PutUint16(b[6:], unix.NLM_F_REQUEST)
+	binary.NativeEndian.PutUint32(b[8:], 1)
+
+	e = unix.Sendto(
+		fd,
+		b,
+		0,
+		&unix.SockaddrNetlink{Family: unix.AF_NETLINK},
+	)
+	if e != nil {
+		return ""
+	}
+
+	//nolint:mnd // 64KiB fits a link with all of its attributes
+	b = make([]byte, 64*1024)
+
+	if size, _, e = unix.Recvfrom(fd, b, 0); e != nil {
+		return ""
+	}
+
+	replies, _, e = nlReplies(
+		b[:size],
+		unix.SizeofIfInfomsg,
+		1,
+		false,
+	)
+	if (e != nil) || (len(replies) == 0) {
+		return ""
+	}
+
+	attrs = nlAttrs(nlAttrs(replies[0])[unix.IFLA_LINKINFO])
+	kind = string(attrs[unix.IFLA_INFO_KIND])
+
+	return strings.TrimRight(kind, "\x00")
+}
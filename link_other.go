@@ -0,0 +1,8 @@
+//go:build !linux
+
+package sysinfo
+
+// linkKind is not supported on this platform.
+func linkKind(_ string) string {
+	return ""
+}
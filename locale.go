@@ -0,0 +1,60 @@
+package sysinfo
+
+import (
+	"os"
+	"strings"
+)
+
+// catalog is a map of field names and unit words to their
+// translations.
+type catalog map[string]string
+
+// label will return the label for the specified field or unit word,
+// using the user-defined Labels, then the catalog for the current
+// language, then English.
+func label(key string) string {
+	if tmp, ok := Labels[key]; ok {
+		return tmp
+	}
+
+	if tmp, ok := catalogs[language()][key]; ok {
+		return tmp
+	}
+
+	if tmp, ok := titleCase[key]; ok {
+		return tmp
+	}
+
+	if tmp, ok := catalogs["en"][key]; ok {
+		return tmp
+	}
+
+	return key
+}
+
+// language will return the configured Language, falling back to the
+// locale environment variables, e.g. "de_DE.UTF-8" becomes "de".
+func language() string {
+	var lang string = Language
+
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if lang != "" {
+			break
+		}
+
+		lang = os.Getenv(env)
+	}
+
+	// Strip territory, codeset, and modifier
+	lang, _, _ = strings.Cut(lang, ".")
+	lang, _, _ = strings.Cut(lang, "@")
+	lang, _, _ = strings.Cut(strings.ReplaceAll(lang, "-", "_"), "_")
+	lang = strings.ToLower(lang)
+
+	switch lang {
+	case "", "c", "posix":
+		return "en"
+	default:
+		return lang
+	}
+}
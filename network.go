@@ -0,0 +1,178 @@
+package sysinfo
+
+import (
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Interface is a struct containing details about a network
+// interface.
+type Interface struct {
+	Addrs   []string `json:"addrs,omitempty"`
+	Duplex  string   `json:"duplex,omitempty"`
+	MAC     string   `json:"mac,omitempty"`
+	MTU     int      `json:"mtu,omitempty"`
+	Name    string   `json:"name"`
+	RxBytes uint64   `json:"rx_bytes"`
+	Speed   int      `json:"speed,omitempty"`
+	State   string   `json:"state,omitempty"`
+	TxBytes uint64   `json:"tx_bytes"`
+	Type    string   `json:"type,omitempty"`
+}
+
+// String will return a string representation of the Interface.
+func (i Interface) String() string {
+	var link []string
+	var out []string
+
+	link = append(link, i.State)
+	if i.Speed > 0 {
+		link = append(link, strconv.Itoa(i.Speed)+"Mb/s")
+	}
+
+	if i.Duplex != "" {
+		link = append(link, i.Duplex+"-duplex")
+	}
+
+	out = append(
+		out,
+		i.Name+" ("+i.Type+") "+joinNonEmpty(" ", link...),
+	)
+	out = append(out, i.MAC)
+
+	if i.MTU > 0 {
+		out = append(out, "mtu "+strconv.Itoa(i.MTU))
+	}
+
+	out = append(
+		out,
+		"rx "+formatBytes(i.RxBytes)+" tx "+formatBytes(i.TxBytes),
+	)
+
+	return joinNonEmpty(", ", out...)
+}
+
+func (s *SysInfo) network() {
+	var e error
+	var ifaces []net.Interface
+	var ips map[string][]string = s.getIPs()
+	var tmp Interface
+
+	s.Net = nil
+
+	if ifaces, e = net.Interfaces(); e != nil {
+		return
+	}
+
+	for _, iface := range ifaces {
+		if (iface.Flags & net.FlagLoopback) != 0 {
+			continue
+		}
+
+		if !keepInterface(iface.Name) {
+			continue
+		}
+
+		tmp = Interface{
+			Addrs: ips[iface.Name],
+			MAC:   iface.HardwareAddr.String(),
+			MTU:   iface.MTU,
+			Name:  iface.Name,
+			State: "down",
+			Type:  "unknown",
+		}
+
+		if (iface.Flags & net.FlagUp) != 0 {
+			tmp.State = "up"
+		}
+
+		// Platform specific details, if available
+		interfaceDetails(&tmp)
+
+		s.Net = append(s.Net, tmp)
+	}
+
+	sort.Slice(
+		s.Net,
+		func(i int, j int) bool {
+			return s.Net[i].Name < s.Net[j].Name
+		},
+	)
+}
+
+// formatDNS will return a string representation of the provided DNS
+// configuration.
+func formatDNS(servers []string, search []string, stub bool) string {
+	var out string = strings.Join(servers, ", ")
+
+	if out == "" {
+		return ""
+	}
+
+	if stub {
+		out += " (systemd-resolved)"
+	}
+
+	if len(search) > 0 {
+		out += ", search " + strings.Join(search, " ")
+	}
+
+	return out
+}
+
+// keepInterface will return whether or not the specified interface
+// should be reported, based on IncludeInterfaces and
+// ExcludeInterfaces.
+func keepInterface(name string) bool {
+	var keep bool = len(IncludeInterfaces) == 0
+
+	for _, glob := range IncludeInterfaces {
+		if ok, _ := path.Match(glob, name); ok {
+			keep = true
+			break
+		}
+	}
+
+	for _, glob := range ExcludeInterfaces {
+		if ok, _ := path.Match(glob, name); ok {
+			return false
+		}
+	}
+
+	return keep
+}
+
+// resolvConf will return the nameservers and search domains from the
+// specified resolv.conf file.
+func resolvConf(fn string) ([]string, []string) {
+	var b []byte
+	var cols []string
+	var e error
+	var search []string
+	var servers []string
+
+	if b, e = os.ReadFile(filepath.Clean(fn)); e != nil {
+		return nil, nil
+	}
+
+	for _, line := range strings.Split(string(b), "\n") {
+		if cols = strings.Fields(line); len(cols) < 2 {
+			continue
+		}
+
+		switch cols[0] {
+		case "domain", "search":
+			// Last one wins
+			search = cols[1:]
+		case "nameserver":
+			servers = append(servers, cols[1])
+		}
+	}
+
+	return servers, search
+}
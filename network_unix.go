@@ -0,0 +1,227 @@
+//go:build !darwin && !windows
+
+package sysinfo
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"net"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/mjwhitta/pathname"
+)
+
+func (s *SysInfo) dns() {
+	var servers []string
+	var search []string
+	var stub bool
+
+	servers, search = resolvConf("/etc/resolv.conf")
+
+	// systemd-resolved's stub hides the real upstream servers
+	for _, server := range servers {
+		if server == resolvedStub {
+			stub = true
+			break
+		}
+	}
+
+	if stub {
+		tmp, _ := resolvConf("/run/systemd/resolve/resolv.conf")
+		if len(tmp) > 0 {
+			servers = tmp
+		}
+	}
+
+	s.DNS = formatDNS(servers, search, stub)
+}
+
+func (s *SysInfo) gateway() {
+	s.Gateway = append(defaultRoutes4(), defaultRoutes6()...)
+}
+
+// defaultRoutes4 will return the default IPv4 gateways, along with
+// their egress interface, from /proc/net/route.
+func defaultRoutes4() []string {
+	var b []byte = make([]byte, net.IPv4len)
+	var cols []string
+	var e error
+	var flags uint64
+	var gw uint64
+	var out []string
+
+	for _, line := range strings.Split(
+		readTrim("/proc/net/route"),
+		"\n",
+	) {
+		// Iface Destination Gateway Flags RefCnt Use Metric Mask ...
+		//nolint:mnd // Need at least 8 columns
+		if cols = strings.Fields(line); len(cols) < 8 {
+			continue
+		}
+
+		if (cols[1] != "00000000") || (cols[7] != "00000000") {
+			continue
+		}
+
+		if flags, e = strconv.ParseUint(cols[3], 16, 32); e != nil {
+			continue
+		}
+
+		if (flags & (rtfUp | rtfGateway)) != (rtfUp | rtfGateway) {
+			continue
+		}
+
+		if gw, e = strconv.ParseUint(cols[2], 16, 32); e != nil {
+			continue
+		}
+
+		// Stored in network byte order, but printed as host order
+		binary.NativeEndian.PutUint32(b, uint32(gw))
+
+		out = append(out, net.IP(b).String()+" ("+cols[0]+")")
+	}
+
+	return out
+}
+
+// defaultRoutes6 will return the default IPv6 gateways, along with
+// their egress interface, from /proc/net/ipv6_route.
+func defaultRoutes6() []string {
+	var cols []string
+	var e error
+	var flags uint64
+	var gw []byte
+	var out []string
+	var zero string = strings.Repeat("0", 32)
+
+	for _, line := range strings.Split(
+		readTrim("/proc/net/ipv6_route"),
+		"\n",
+	) {
+		// Dest DestLen Src SrcLen NextHop Metric Ref Use Flags Iface
+		//nolint:mnd // Need 10 columns
+		if cols = strings.Fields(line); len(cols) != 10 {
+			continue
+		}
+
+		if (cols[0] != zero) || (cols[1] != "00") {
+			continue
+		}
+
+		if cols[9] == "lo" {
+			continue
+		}
+
+		if flags, e = strconv.ParseUint(cols[8], 16, 32); e != nil {
+			continue
+		}
+
+		if ((flags & rtfUp) == 0) || ((flags & rtfReject) != 0) {
+			continue
+		}
+
+		if gw, e = hex.DecodeString(cols[4]); e != nil {
+			continue
+		}
+
+		if cols[4] == zero {
+			// Point-to-point, no next hop
+			out = append(out, "on-link ("+cols[9]+")")
+			continue
+		}
+
+		out = append(out, net.IP(gw).String()+" ("+cols[9]+")")
+	}
+
+	return out
+}
+
+// interfaceDetails will populate the provided Interface using sysfs.
+func interfaceDetails(iface *Interface) {
+	var dir string = filepath.Join(sysClassNet, iface.Name)
+	var tmp string
+
+	if tmp = readTrim(filepath.Join(dir, "operstate")); tmp != "" {
+		iface.State = tmp
+	}
+
+	// Errors if the link is down or the driver doesn't report it
+	tmp = readTrim(filepath.Join(dir, "speed"))
+	if iface.Speed, _ = strconv.Atoi(tmp); iface.Speed < 0 {
+		iface.Speed = 0
+	}
+
+	iface.Duplex = readTrim(filepath.Join(dir, "duplex"))
+	if iface.Duplex == "unknown" {
+		iface.Duplex = ""
+	}
+
+	tmp = readTrim(filepath.Join(dir, "statistics", "rx_bytes"))
+	iface.RxBytes, _ = strconv.ParseUint(tmp, 10, 64)
+
+	tmp = readTrim(filepath.Join(dir, "statistics", "tx_bytes"))
+	iface.TxBytes, _ = strconv.ParseUint(tmp, 10, 64)
+
+	iface.Type = interfaceType(dir)
+}
+
+// interfaceType will determine the type of the interface described by
+// the specified sysfs directory.
+func interfaceType(dir string) string {
+	var devtype string
+	var exists func(name string) bool = func(name string) bool {
+		ok, _ := pathname.DoesExist(filepath.Join(dir, name))
+		return ok
+	}
+
+	for _, line := range strings.Split(
+		readTrim(filepath.Join(dir, "uevent")),
+		"\n",
+	) {
+		if tmp, ok := strings.CutPrefix(line, "DEVTYPE="); ok {
+			devtype = tmp
+		}
+	}
+
+	switch {
+	case devtype == "wlan", exists("wireless"), exists("phy80211"):
+		return "wifi"
+	case devtype == "bridge", exists("bridge"):
+		return "bridge"
+	case devtype != "":
+		// bond, vlan, wireguard, etc
+		return devtype
+	case exists("tun_flags"):
+		if readTrim(filepath.Join(dir, "type")) == "1" {
+			return "tap"
+		}
+
+		return "tun"
+	}
+
+	// See include/uapi/linux/if_arp.h
+	switch readTrim(filepath.Join(dir, "type")) {
+	case "1":
+		if exists("device") {
+			return "ethernet"
+		}
+
+		// Such as veth, macvlan, or ipvlan
+		if kind := linkKind(filepath.Base(dir)); kind != "" {
+			return kind
+		}
+
+		return "virtual"
+	case "512":
+		return "ppp"
+	case "772":
+		return "loopback"
+	case "65534":
+		return "tunnel"
+	default:
+		return "other"
+	}
+}
@@ -0,0 +1,212 @@
+//go:build !darwin && !windows
+
+package sysinfo
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+func (s *SysInfo) packages() {
+	var brew []string = []string{
+		"/home/linuxbrew/.linuxbrew/Cellar",
+		"/home/linuxbrew/.linuxbrew/Caskroom",
+	}
+	var flatpak []string = []string{
+		"/var/lib/flatpak/app",
+		"/var/lib/flatpak/runtime",
+	}
+	var out []string
+
+	// Per-user installs, if there is a home directory
+	if home, e := os.UserHomeDir(); e == nil {
+		brew = append(
+			brew,
+			filepath.Join(home, ".linuxbrew/Cellar"),
+			filepath.Join(home, ".linuxbrew/Caskroom"),
+		)
+		flatpak = append(
+			flatpak,
+			filepath.Join(home, ".local/share/flatpak/app"),
+			filepath.Join(home, ".local/share/flatpak/runtime"),
+		)
+	}
+
+	out = appendPackages(out, countDpkg(), "dpkg")
+	out = appendPackages(out, s.countRPM(), "rpm")
+	out = appendPackages(
+		out,
+		countDirs("/var/lib/pacman/local"),
+		"pacman",
+	)
+	out = appendPackages(out, countApk(), "apk")
+	out = appendPackages(out, countDirs(flatpak...), "flatpak")
+	out = appendPackages(out, countSnap(), "snap")
+	out = appendPackages(out, s.countNix(), "nix")
+	out = appendPackages(out, countDirs(brew...), "brew")
+
+	s.Packages = strings.Join(out, ", ")
+}
+
+// countNix will count the packages installed in the system and user
+// Nix profiles.
+func (s *SysInfo) countNix() int {
+	var count int
+	var out string
+	var profiles []string = []string{
+		"/run/current-system/sw",
+		"/nix/var/nix/profiles/default",
+	}
+
+	if home, e := os.UserHomeDir(); e == nil {
+		profiles = append(
+			profiles,
+			filepath.Join(home, ".nix-profile"),
+		)
+	}
+
+	for _, profile := range profiles {
+		if _, e := os.Stat(profile); e != nil {
+			continue
+		}
+
+		// Newer profiles describe their contents in a manifest
+		if n, ok := countNixManifest(profile); ok {
+			count += n
+			continue
+		}
+
+		out = s.execTimeout(
+			5*time.Second,
+			"nix-store",
+			"--query",
+			"--requisites",
+			profile,
+		)
+		if out != "" {
+			count += len(strings.Split(out, "\n"))
+		}
+	}
+
+	return count
+}
+
+// countRPM will count the installed RPM packages. The rpmdb is
+// SQLite or Berkeley DB, so rpm is used rather than parsing it.
+func (s *SysInfo) countRPM() int {
+	var out string
+
+	if _, e := os.Stat("/var/lib/rpm"); e != nil {
+		return 0
+	}
+
+	// Large databases can take a few seconds
+	out = s.execTimeout(5*time.Second, "rpm", "--query", "--all")
+	if out == "" {
+		return 0
+	}
+
+	return len(strings.Split(out, "\n"))
+}
+
+// countApk will count the installed Alpine packages.
+func countApk() int {
+	return countLines("/lib/apk/db/installed", "P:", "")
+}
+
+// countDpkg will count the installed Debian packages.
+func countDpkg() int {
+	// Also includes held packages
+	return countLines("/var/lib/dpkg/status", "Status:", " installed")
+}
+
+// countLines will count the lines in the specified file that start
+// and end with the provided prefix and suffix.
+func countLines(fn string, prefix string, suffix string) int {
+	var count int
+	var e error
+	var f *os.File
+	var scanner *bufio.Scanner
+
+	if f, e = os.Open(filepath.Clean(fn)); e != nil {
+		return 0
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	scanner = bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if strings.HasPrefix(line, prefix) &&
+			strings.HasSuffix(line, suffix) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// countNixManifest will count the elements in a Nix profile's
+// manifest.json, if it exists.
+func countNixManifest(profile string) (int, bool) {
+	var b []byte
+	var e error
+	var manifest struct {
+		Elements json.RawMessage `json:"elements"`
+	}
+	var elements []any
+	var named map[string]any
+
+	b, e = os.ReadFile(filepath.Join(profile, "manifest.json"))
+	if e != nil {
+		return 0, false
+	}
+
+	if e = json.Unmarshal(b, &manifest); e != nil {
+		return 0, false
+	}
+
+	// Version 2 uses a list, version 3 uses a map
+	if e = json.Unmarshal(manifest.Elements, &elements); e == nil {
+		return len(elements), true
+	}
+
+	if e = json.Unmarshal(manifest.Elements, &named); e == nil {
+		return len(named), true
+	}
+
+	return 0, false
+}
+
+// countSnap will count the installed snaps.
+func countSnap() int {
+	var count int
+	var entries []os.DirEntry
+	var e error
+
+	for _, dir := range []string{"/snap", "/var/lib/snapd/snap"} {
+		if entries, e = os.ReadDir(dir); e != nil {
+			continue
+		}
+
+		for _, entry := range entries {
+			switch entry.Name() {
+			case "bin", "README":
+			default:
+				if entry.IsDir() {
+					count++
+				}
+			}
+		}
+
+		break
+	}
+
+	return count
+}
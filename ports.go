@@ -0,0 +1,52 @@
+package sysinfo
+
+import (
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Port is a struct containing details about a listening socket.
+type Port struct {
+	Addr    string `json:"addr"`
+	PID     int    `json:"pid,omitempty"`
+	Port    int    `json:"port"`
+	Process string `json:"process,omitempty"`
+	Proto   string `json:"proto"`
+	UID     int    `json:"uid"`
+
+	inode uint64
+}
+
+// String will return a string representation of the Port.
+func (p Port) String() string {
+	return joinNonEmpty(
+		" ",
+		strconv.Itoa(p.Port)+"/"+p.Proto,
+		p.Process,
+	)
+}
+
+// isLoopback will return whether or not the Port is only reachable
+// from the local host.
+func (p Port) isLoopback() bool {
+	var ip net.IP = net.ParseIP(p.Addr)
+
+	return (ip != nil) && ip.IsLoopback()
+}
+
+// formatPorts will return a compact list of the provided Ports. The
+// same service listening on multiple addresses is only listed once.
+func formatPorts(ports []Port) string {
+	var out []string
+	var seen map[string]bool = map[string]bool{}
+
+	for _, p := range ports {
+		if !seen[p.String()] {
+			out = append(out, p.String())
+			seen[p.String()] = true
+		}
+	}
+
+	return strings.Join(out, ", ")
+}
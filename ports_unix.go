@@ -0,0 +1,173 @@
+//go:build !darwin && !windows
+
+package sysinfo
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func (s *SysInfo) ports() {
+	var owners map[uint64]string = socketOwners()
+
+	s.Ports = nil
+
+	for _, proto := range []string{"tcp", "tcp6", "udp", "udp6"} {
+		for _, p := range listeners(proto) {
+			if HideLoopbackPorts && p.isLoopback() {
+				continue
+			}
+
+			if pid, ok := owners[p.inode]; ok {
+				p.PID, _ = strconv.Atoi(pid)
+				p.Process = procComm(pid)
+			}
+
+			s.Ports = append(s.Ports, p)
+		}
+	}
+
+	sort.SliceStable(
+		s.Ports,
+		func(i int, j int) bool {
+			if s.Ports[i].Port != s.Ports[j].Port {
+				return s.Ports[i].Port < s.Ports[j].Port
+			}
+
+			return s.Ports[i].Proto < s.Ports[j].Proto
+		},
+	)
+}
+
+// listeners will return the listening sockets from the specified
+// /proc/net file. UDP sockets are considered listening if they
+// aren't connected to a remote address.
+func listeners(proto string) []Port {
+	var addr net.IP
+	var cols []string
+	var e error
+	var out []Port
+	var port int
+	var tmp Port
+
+	for _, line := range strings.Split(
+		readTrim(filepath.Join("/proc/net", proto)),
+		"\n",
+	) {
+		// sl local rem st tx:rx tr:when retrnsmt uid timeout inode
+		//nolint:mnd // Need at least 10 columns
+		if cols = strings.Fields(line); len(cols) < 10 {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(proto, "tcp") && (cols[3] == "0A"):
+			// TCP_LISTEN
+		case strings.HasPrefix(proto, "udp") && (cols[3] == "07"):
+			// TCP_CLOSE, so only keep unconnected sockets
+			if _, port, e = procNetAddr(cols[2]); (e != nil) ||
+				(port != 0) {
+				continue
+			}
+		default:
+			continue
+		}
+
+		if addr, port, e = procNetAddr(cols[1]); e != nil {
+			continue
+		}
+
+		tmp = Port{
+			Addr:  addr.String(),
+			Port:  port,
+			Proto: strings.TrimSuffix(proto, "6"),
+		}
+
+		tmp.UID, _ = strconv.Atoi(cols[7])
+		tmp.inode, _ = strconv.ParseUint(cols[9], 10, 64)
+
+		out = append(out, tmp)
+	}
+
+	return out
+}
+
+// procNetAddr will parse an address from /proc/net/{tcp,udp}[6],
+// which is printed as 32-bit words in host byte order, followed by
+// the port.
+func procNetAddr(str string) (net.IP, int, error) {
+	var b []byte
+	var e error
+	var hexAddr string
+	var hexPort string
+	var port uint64
+
+	hexAddr, hexPort, _ = strings.Cut(str, ":")
+
+	if b, e = hex.DecodeString(hexAddr); e != nil {
+		return nil, 0, e
+	}
+
+	for i := 0; i+net.IPv4len <= len(b); i += net.IPv4len {
+		binary.NativeEndian.PutUint32(
+			b[i:],
+			binary.BigEndian.Uint32(b[i:]),
+		)
+	}
+
+	if port, e = strconv.ParseUint(hexPort, 16, 16); e != nil {
+		return nil, 0, e
+	}
+
+	return net.IP(b), int(port), nil
+}
+
+// socketOwners will return a map of socket inodes to the PID of the
+// owning process. Only processes visible to the current user are
+// included.
+func socketOwners() map[uint64]string {
+	var dir string
+	var e error
+	var entries []os.DirEntry
+	var inode uint64
+	var link string
+	var ok bool
+	var out map[uint64]string = map[uint64]string{}
+
+	for _, pid := range pids() {
+		dir = filepath.Join("/proc", pid, "fd")
+
+		if entries, e = os.ReadDir(dir); e != nil {
+			continue
+		}
+
+		for _, entry := range entries {
+			link, e = os.Readlink(filepath.Join(dir, entry.Name()))
+			if e != nil {
+				continue
+			}
+
+			if link, ok = strings.CutPrefix(link, "socket:["); !ok {
+				continue
+			}
+
+			link = strings.TrimSuffix(link, "]")
+
+			if inode, e = strconv.ParseUint(link, 10, 64); e != nil {
+				continue
+			}
+
+			if _, ok = out[inode]; !ok {
+				out[inode] = pid
+			}
+		}
+	}
+
+	return out
+}
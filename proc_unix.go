@@ -0,0 +1,139 @@
+//go:build !darwin && !windows
+
+package sysinfo
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// capabilities will return the notable capabilities in the effective
+// set of the current process, and whether or not any are missing.
+// Capabilities unknown to the running kernel are never missing.
+func capabilities() ([]string, bool) {
+	var e error
+	var last uint64
+	var mask uint64
+	var missing bool
+	var out []string
+
+	mask, e = strconv.ParseUint(procStatus("self", "CapEff"), 16, 64)
+	if e != nil {
+		return nil, false
+	}
+
+	// Such as CAP_PERFMON and CAP_BPF, before Linux 5.8
+	last, e = strconv.ParseUint(
+		readTrim("/proc/sys/kernel/cap_last_cap"),
+		10,
+		64,
+	)
+	if e != nil {
+		last = 63 //nolint:mnd // Highest bit in the mask
+	}
+
+	for bit, name := range notableCaps {
+		if uint64(bit) > last {
+			continue
+		}
+
+		if (mask & (1 << bit)) == 0 {
+			missing = true
+			continue
+		}
+
+		out = append(out, name)
+	}
+
+	sort.Strings(out)
+
+	return out, missing
+}
+
+// procComm will return the command name of the specified process.
+func procComm(pid string) string {
+	return readTrim(filepath.Join("/proc", pid, "comm"))
+}
+
+// procExe will return the path to the executable of the specified
+// process.
+func procExe(pid string) string {
+	var exe string
+
+	exe, _ = os.Readlink(filepath.Join("/proc", pid, "exe"))
+
+	return exe
+}
+
+// procParent will return the parent PID of the specified process.
+func procParent(pid string) string {
+	return procStatus(pid, "PPid")
+}
+
+// procStatus will return the value of the specified key from
+// /proc/<pid>/status.
+func procStatus(pid string, key string) string {
+	var status string = filepath.Join("/proc", pid, "status")
+
+	for _, line := range strings.Split(readTrim(status), "\n") {
+		if val, ok := strings.CutPrefix(line, key+":"); ok {
+			return strings.TrimSpace(val)
+		}
+	}
+
+	return ""
+}
+
+// procUID will return the real UID of the specified process, or -1
+// if it can't be determined.
+func procUID(pid string) int {
+	var e error
+	var fields []string = strings.Fields(procStatus(pid, "Uid"))
+	var uid int
+
+	if len(fields) == 0 {
+		return -1
+	}
+
+	if uid, e = strconv.Atoi(fields[0]); e != nil {
+		return -1
+	}
+
+	return uid
+}
+
+// procTree will return the specified process and its ancestors,
+// nearest first.
+func procTree(pid string) []string {
+	var out []string
+
+	//nolint:mnd // Guard against loops
+	for (pid != "") && (pid != "0") && (len(out) < 64) {
+		out = append(out, pid)
+		pid = procParent(pid)
+	}
+
+	return out
+}
+
+// pids will return the IDs of all running processes.
+func pids() []string {
+	var e error
+	var entries []os.DirEntry
+	var out []string
+
+	if entries, e = os.ReadDir("/proc"); e != nil {
+		return nil
+	}
+
+	for _, entry := range entries {
+		if _, e = strconv.Atoi(entry.Name()); e == nil {
+			out = append(out, entry.Name())
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,240 @@
+package sysinfo
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mjwhitta/errors"
+)
+
+func (s *SysInfo) publicIP() {
+	var cache string
+	var e error
+	var fn string
+	var ip string
+
+	s.PublicIP = ""
+
+	if PublicIPSource == "" {
+		return
+	}
+
+	if fn, e = os.UserCacheDir(); e == nil {
+		cache = filepath.Join(fn, "sysinfo", "public_ip")
+	}
+
+	if ip = readPublicIPCache(cache); ip != "" {
+		s.PublicIP = ip
+		return
+	}
+
+	if ip, e = lookupPublicIP(PublicIPSource); e != nil {
+		return
+	}
+
+	s.PublicIP = ip
+
+	writePublicIPCache(cache, ip)
+}
+
+// lookupPublicIP will query the specified source for the public IP.
+// Sources are either HTTP(S) URLs which return the IP as plain text,
+// or DNS URLs of the form
+// dns://resolver[:port]/name[?type=A|AAAA|TXT].
+func lookupPublicIP(source string) (string, error) {
+	var cancel context.CancelFunc
+	var ctx context.Context
+	var e error
+	var ip string
+	var u *url.URL
+
+	if u, e = url.Parse(source); e != nil {
+		return "", errors.Newf("invalid source %s: %w", source, e)
+	}
+
+	ctx, cancel = context.WithTimeout(
+		context.Background(),
+		PublicIPTimeout,
+	)
+	defer cancel()
+
+	switch u.Scheme {
+	case "dns":
+		ip, e = lookupPublicIPDNS(ctx, u)
+	case "http", "https":
+		ip, e = lookupPublicIPHTTP(ctx, u)
+	default:
+		return "", errors.Newf("unsupported source %s", source)
+	}
+
+	if e != nil {
+		return "", e
+	}
+
+	// Validate the response
+	ip = strings.Trim(strings.TrimSpace(ip), "\"")
+	if net.ParseIP(ip) == nil {
+		return "", errors.Newf("invalid IP from %s: %s", source, ip)
+	}
+
+	return ip, nil
+}
+
+func lookupPublicIPDNS(
+	ctx context.Context, u *url.URL,
+) (string, error) {
+	var e error
+	var ips []net.IP
+	var name string = strings.Trim(u.Path, "/")
+	var family string
+	var resolver *net.Resolver
+	var server string = u.Host
+	var txts []string
+
+	if u.Port() == "" {
+		server = net.JoinHostPort(u.Hostname(), "53")
+	}
+
+	resolver = &net.Resolver{
+		Dial: func(
+			ctx context.Context, network string, _ string,
+		) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, server)
+		},
+		PreferGo: true,
+	}
+
+	switch strings.ToUpper(u.Query().Get("type")) {
+	case "", "A":
+		family = "ip4"
+	case "AAAA":
+		family = "ip6"
+	case "TXT":
+		if txts, e = resolver.LookupTXT(ctx, name); e != nil {
+			return "", errors.Newf("DNS lookup failed: %w", e)
+		}
+
+		// Some servers include other records, such as the subnet
+		for _, txt := range txts {
+			if net.ParseIP(strings.TrimSpace(txt)) != nil {
+				return txt, nil
+			}
+		}
+
+		return "", errors.Newf("no IP in TXT records for %s", u)
+	default:
+		return "", errors.Newf("unsupported DNS type for %s", u)
+	}
+
+	if ips, e = resolver.LookupIP(ctx, family, name); e != nil {
+		return "", errors.Newf("DNS lookup failed: %w", e)
+	}
+
+	if len(ips) == 0 {
+		return "", errors.Newf("no DNS records for %s", u)
+	}
+
+	return ips[0].String(), nil
+}
+
+func lookupPublicIPHTTP(
+	ctx context.Context, u *url.URL,
+) (string, error) {
+	var b []byte
+	var e error
+	var req *http.Request
+	var res *http.Response
+
+	req, e = http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		u.String(),
+		nil,
+	)
+	if e != nil {
+		return "", errors.Newf("failed to create request: %w", e)
+	}
+
+	if res, e = http.DefaultClient.Do(req); e != nil {
+		return "", errors.Newf("failed to query %s: %w", u, e)
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return "", errors.Newf("%s returned %s", u, res.Status)
+	}
+
+	// An IP is never very long
+	//nolint:mnd // 256 bytes is plenty
+	if b, e = io.ReadAll(io.LimitReader(res.Body, 256)); e != nil {
+		return "", errors.Newf("failed to read response: %w", e)
+	}
+
+	return string(b), nil
+}
+
+// readPublicIPCache will return the cached public IP, if the cache
+// exists, is for the current source, and hasn't expired.
+func readPublicIPCache(fn string) string {
+	var b []byte
+	var e error
+	var fi os.FileInfo
+	var lines []string
+
+	if (fn == "") || (PublicIPCacheTTL <= 0) {
+		return ""
+	}
+
+	if fi, e = os.Stat(fn); e != nil {
+		return ""
+	}
+
+	if time.Since(fi.ModTime()) > PublicIPCacheTTL {
+		return ""
+	}
+
+	if b, e = os.ReadFile(filepath.Clean(fn)); e != nil {
+		return ""
+	}
+
+	// Source on the first line, IP on the second
+	lines = strings.Split(strings.TrimSpace(string(b)), "\n")
+
+	//nolint:mnd // Source and IP == 2 lines
+	if (len(lines) != 2) || (lines[0] != PublicIPSource) {
+		return ""
+	}
+
+	// Ignore a corrupt cache
+	if net.ParseIP(lines[1]) == nil {
+		return ""
+	}
+
+	return lines[1]
+}
+
+// writePublicIPCache will cache the public IP for the current source.
+// Errors are ignored as the cache is only an optimization.
+func writePublicIPCache(fn string, ip string) {
+	if (fn == "") || (PublicIPCacheTTL <= 0) {
+		return
+	}
+
+	//nolint:mnd // u=rwx,go=-
+	if e := os.MkdirAll(filepath.Dir(fn), 0o700); e != nil {
+		return
+	}
+
+	//nolint:mnd // u=rw,go=-
+	_ = os.WriteFile(fn, []byte(PublicIPSource+"\n"+ip+"\n"), 0o600)
+}
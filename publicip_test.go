@@ -0,0 +1,285 @@
+package sysinfo
+
+import (
+	"encoding/binary"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeDNS will answer DNS queries on a local UDP port with the
+// provided records, keyed by query type, and return its address.
+func fakeDNS(t *testing.T, records map[uint16][][]byte) string {
+	t.Helper()
+
+	var conn net.PacketConn
+	var e error
+
+	if conn, e = net.ListenPacket("udp", "127.0.0.1:0"); e != nil {
+		t.Fatal(e)
+	}
+
+	t.Cleanup(
+		func() {
+			_ = conn.Close()
+		},
+	)
+
+	go func() {
+		var addr net.Addr
+		var b []byte = make([]byte, 512)
+		var e error
+		var end int
+		var n int
+		var reply []byte
+		var typ uint16
+
+		for {
+			if n, addr, e = conn.ReadFrom(b); e != nil {
+				return
+			}
+
+			// Header, then the name, type, and class of the question
+			end = 12
+			for (end < n) && (b[end] != 0) {
+				end += int(b[end]) + 1
+			}
+
+			if end += 5; end > n {
+				continue
+			}
+
+			typ = binary.BigEndian.Uint16(b[end-4:])
+
+			// Response with only the question and the answers
+			reply = append([]byte{}, b[:end]...)
+			binary.BigEndian.PutUint16(reply[2:], 0x8180)
+			binary.BigEndian.PutUint16(reply[6:], 0)
+			binary.BigEndian.PutUint32(reply[8:], 0)
+
+			for _, rdata := range records[typ] {
+				reply[7]++
+				reply = append(reply, 0xc0, 12) // Question name
+				reply = binary.BigEndian.AppendUint16(reply, typ)
+				reply = binary.BigEndian.AppendUint16(reply, 1)
+				reply = binary.BigEndian.AppendUint32(reply, 60)
+				reply = binary.BigEndian.AppendUint16(
+					reply,
+					uint16(len(rdata)),
+				)
+				reply = append(reply, rdata...)
+			}
+
+			_, _ = conn.WriteTo(reply, addr)
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+// fakePublicIP will start a server returning the provided IP, and
+// point PublicIPSource and the user cache directory at temporary
+// locations. It returns the number of requests served so far.
+func fakePublicIP(t *testing.T, ip *atomic.Value) *atomic.Int32 {
+	t.Helper()
+
+	var hits *atomic.Int32 = &atomic.Int32{}
+	var srv *httptest.Server
+	var tmp string = t.TempDir()
+
+	srv = httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, _ *http.Request) {
+				hits.Add(1)
+				_, _ = w.Write([]byte(ip.Load().(string) + "\n"))
+			},
+		),
+	)
+	t.Cleanup(srv.Close)
+
+	// Used by os.UserCacheDir
+	t.Setenv("HOME", tmp)
+	t.Setenv("LocalAppData", tmp)
+	t.Setenv("XDG_CACHE_HOME", tmp)
+
+	swap(t, &PublicIPCacheTTL, time.Minute)
+	swap(t, &PublicIPSource, srv.URL)
+
+	return hits
+}
+
+// txtRecord will encode the provided string as TXT record data.
+func txtRecord(s string) []byte {
+	return append([]byte{byte(len(s))}, s...)
+}
+
+func TestLookupPublicIP(t *testing.T) {
+	var e error
+	var ip string
+	var srv *httptest.Server
+	var tests map[string]string = map[string]string{
+		"203.0.113.7\n":   "203.0.113.7",
+		"\"2001:db8::1\"": "2001:db8::1",
+		"<html>":          "",
+	}
+
+	for body, expected := range tests {
+		srv = httptest.NewServer(
+			http.HandlerFunc(
+				func(w http.ResponseWriter, _ *http.Request) {
+					_, _ = w.Write([]byte(body))
+				},
+			),
+		)
+
+		ip, e = lookupPublicIP(srv.URL)
+		srv.Close()
+
+		if (expected == "") && (e == nil) {
+			t.Errorf("%q: expected error, got %s", body, ip)
+		} else if (expected != "") && (e != nil) {
+			t.Errorf("%q: %s", body, e)
+		} else if ip != expected {
+			t.Errorf("%q: got %s, want %s", body, ip, expected)
+		}
+	}
+}
+
+func TestLookupPublicIPDNS(t *testing.T) {
+	var addr string
+	var e error
+	var ip string
+	var subnet []byte = txtRecord("edns0-client-subnet 198.51.100.0")
+	var tests map[string]string = map[string]string{
+		"":           "203.0.113.7",
+		"?type=A":    "203.0.113.7",
+		"?type=AAAA": "2001:db8::1",
+		"?type=MX":   "",
+		"?type=TXT":  "203.0.113.9",
+	}
+
+	// A, TXT, and AAAA records
+	addr = fakeDNS(
+		t,
+		map[uint16][][]byte{
+			1:  {net.ParseIP("203.0.113.7").To4()},
+			16: {subnet, txtRecord("203.0.113.9")},
+			28: {net.ParseIP("2001:db8::1")},
+		},
+	)
+
+	for query, expected := range tests {
+		ip, e = lookupPublicIP("dns://" + addr + "/myip.test" + query)
+
+		if (expected == "") && (e == nil) {
+			t.Errorf("%q: expected error, got %s", query, ip)
+		} else if (expected != "") && (e != nil) {
+			t.Errorf("%q: %s", query, e)
+		} else if ip != expected {
+			t.Errorf("%q: got %s, want %s", query, ip, expected)
+		}
+	}
+
+	// TXT records without an IP
+	addr = fakeDNS(t, map[uint16][][]byte{16: {subnet}})
+
+	ip, e = lookupPublicIP("dns://" + addr + "/myip.test?type=TXT")
+	if e == nil {
+		t.Errorf("expected error, got %s", ip)
+	}
+}
+
+func TestLookupPublicIPTimeout(t *testing.T) {
+	var e error
+	var srv *httptest.Server
+	var start time.Time
+
+	srv = httptest.NewServer(
+		http.HandlerFunc(
+			func(_ http.ResponseWriter, r *http.Request) {
+				select {
+				case <-r.Context().Done():
+				case <-time.After(5 * time.Second):
+				}
+			},
+		),
+	)
+	defer srv.Close()
+
+	swap(t, &PublicIPTimeout, 50*time.Millisecond)
+
+	start = time.Now()
+
+	if _, e = lookupPublicIP(srv.URL); e == nil {
+		t.Error("expected timeout")
+	}
+
+	if time.Since(start) > time.Second {
+		t.Errorf("timeout took %s", time.Since(start))
+	}
+}
+
+func TestPublicIPCache(t *testing.T) {
+	var cache string
+	var e error
+	var hits *atomic.Int32
+	var ip *atomic.Value = &atomic.Value{}
+	var old time.Time
+	var s *SysInfo = &SysInfo{}
+
+	ip.Store("203.0.113.7")
+	hits = fakePublicIP(t, ip)
+
+	s.publicIP()
+
+	if s.PublicIP != "203.0.113.7" {
+		t.Fatalf("got %q, want 203.0.113.7", s.PublicIP)
+	}
+
+	// Cache hit within the TTL
+	ip.Store("203.0.113.8")
+	s.publicIP()
+
+	if (s.PublicIP != "203.0.113.7") || (hits.Load() != 1) {
+		t.Errorf("got %q after %d requests", s.PublicIP, hits.Load())
+	}
+
+	// Expired cache
+	if cache, e = os.UserCacheDir(); e != nil {
+		t.Fatal(e)
+	}
+
+	cache = filepath.Join(cache, "sysinfo", "public_ip")
+	old = time.Now().Add(-2 * PublicIPCacheTTL)
+
+	if e = os.Chtimes(cache, old, old); e != nil {
+		t.Fatal(e)
+	}
+
+	s.publicIP()
+
+	if (s.PublicIP != "203.0.113.8") || (hits.Load() != 2) {
+		t.Errorf("got %q after %d requests", s.PublicIP, hits.Load())
+	}
+
+	// Corrupt cache
+	e = os.WriteFile(
+		cache,
+		[]byte(PublicIPSource+"\n<html>\n"),
+		0o600,
+	)
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	s.publicIP()
+
+	if (s.PublicIP != "203.0.113.8") || (hits.Load() != 3) {
+		t.Errorf("got %q after %d requests", s.PublicIP, hits.Load())
+	}
+}
@@ -0,0 +1,138 @@
+package sysinfo
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Reboot is a struct containing the reasons a reboot is pending.
+type Reboot struct {
+	DeletedLibs []string `json:"deleted_libs,omitempty"`
+	Kernel      string   `json:"kernel,omitempty"`
+	Packages    []string `json:"packages,omitempty"`
+	Requested   bool     `json:"requested"`
+	Required    bool     `json:"required"`
+}
+
+// String will return a string representation of the Reboot.
+func (r Reboot) String() string {
+	var reasons []string
+
+	if !r.Required {
+		return label("not required")
+	}
+
+	if r.Kernel != "" {
+		reasons = append(
+			reasons,
+			fmt.Sprintf(label("kernel installed"), r.Kernel),
+		)
+	}
+
+	switch {
+	case len(r.Packages) > 0:
+		reasons = append(
+			reasons,
+			fmt.Sprintf(
+				label("requested by"),
+				strings.Join(r.Packages, ", "),
+			),
+		)
+	case r.Requested:
+		reasons = append(reasons, label("requested"))
+	}
+
+	switch len(r.DeletedLibs) {
+	case 0:
+	case 1:
+		reasons = append(reasons, label("deleted lib"))
+	default:
+		reasons = append(
+			reasons,
+			fmt.Sprintf(
+				label("deleted libs"),
+				len(r.DeletedLibs),
+			),
+		)
+	}
+
+	return label("reboot required") +
+		" (" + strings.Join(reasons, ", ") + ")"
+}
+
+// compareVersions will compare two version strings, such as kernel
+// releases, similar to sort -V. Numeric parts are compared as
+// numbers, and release candidates, such as 6.10-rc3, are older than
+// the release.
+func compareVersions(a string, b string) int {
+	var ea error
+	var eb error
+	var na int
+	var nb int
+	var pa []string = reVersionParts.FindAllString(a, -1)
+	var pb []string = reVersionParts.FindAllString(b, -1)
+	var xa string
+	var xb string
+
+	for i := range max(len(pa), len(pb)) {
+		xa, xb = "", ""
+
+		if i < len(pa) {
+			xa = pa[i]
+		}
+
+		if i < len(pb) {
+			xb = pb[i]
+		}
+
+		na, ea = strconv.Atoi(xa)
+		nb, eb = strconv.Atoi(xb)
+
+		switch {
+		case isRC(xa) != isRC(xb):
+			if isRC(xa) {
+				return -1
+			}
+
+			return 1
+		case (xa == "") || (xb == ""):
+			return cmp.Compare(len(pa), len(pb))
+		case (ea == nil) && (eb == nil):
+			if na != nb {
+				return cmp.Compare(na, nb)
+			}
+		case xa != xb:
+			return strings.Compare(xa, xb)
+		}
+	}
+
+	return 0
+}
+
+// isRC will return whether or not the provided version part marks a
+// release candidate, such as "-rc" or "~rc".
+func isRC(part string) bool {
+	return strings.HasSuffix(strings.ToLower(part), "rc")
+}
+
+// kernelFlavor will return the flavor of the provided kernel release,
+// such as "generic" for 6.8.0-45-generic or "cloud-amd" for
+// 6.1.0-18-cloud-amd64. Releases of different flavors are never
+// compared.
+func kernelFlavor(release string) string {
+	var out []string
+
+	if _, release, _ = strings.Cut(release, "-"); release == "" {
+		return ""
+	}
+
+	for _, word := range reFlavor.FindAllString(release, -1) {
+		if word = strings.ToLower(word); word != "rc" {
+			out = append(out, word)
+		}
+	}
+
+	return strings.Join(out, "-")
+}
@@ -0,0 +1,91 @@
+package sysinfo
+
+import "testing"
+
+func TestCompareVersions(t *testing.T) {
+	var tests map[[2]string]int = map[[2]string]int{
+		{"6.8.0-45-generic", "6.8.0-45-generic"}: 0,
+		{"6.8.0-49-generic", "6.8.0-45-generic"}: 1,
+		{"6.8.0-9-generic", "6.8.0-45-generic"}:  -1,
+		{"6.10.0", "6.9.12"}:                     1,
+		{"6.10.0-rc3", "6.10.0"}:                 -1,
+		{"6.10.0", "6.10.0-rc3"}:                 1,
+		{"6.10.0-rc3", "6.10.0-rc7"}:             -1,
+		{"6.10.0-rc7", "6.9.12"}:                 1,
+		{"6.10.0-1", "6.10.0"}:                   1,
+	}
+
+	for test, expected := range tests {
+		if n := compareVersions(test[0], test[1]); n != expected {
+			t.Errorf(
+				"compareVersions(%q, %q) = %d, want %d",
+				test[0],
+				test[1],
+				n,
+				expected,
+			)
+		}
+	}
+}
+
+func TestKernelFlavor(t *testing.T) {
+	var tests map[string]string = map[string]string{
+		"5.14.0-427.el9.x86_64":  "el-x",
+		"6.1.0-18-amd64":         "amd",
+		"6.1.0-18-cloud-amd64":   "cloud-amd",
+		"6.10.0":                 "",
+		"6.10.0-rc3":             "",
+		"6.6.30-1-lts":           "lts",
+		"6.8.0-45-generic":       "generic",
+		"6.8.0-45-lowlatency":    "lowlatency",
+		"6.9.1-arch1-1":          "arch",
+		"6.9.1-arch2-1":          "arch",
+		"6.10.0-rc3-next-202406": "next",
+	}
+
+	for release, expected := range tests {
+		if flavor := kernelFlavor(release); flavor != expected {
+			t.Errorf(
+				"kernelFlavor(%q) = %q, want %q",
+				release,
+				flavor,
+				expected,
+			)
+		}
+	}
+}
+
+func TestRebootString(t *testing.T) {
+	var r Reboot = Reboot{
+		DeletedLibs: []string{"/lib/libc.so.6", "/lib/libm.so.6"},
+		Kernel:      "6.8.0-49-generic",
+		Packages:    []string{"linux-image-6.8.0-49-generic"},
+		Requested:   true,
+		Required:    true,
+	}
+	var tests map[string]string = map[string]string{
+		"en": "reboot required (kernel 6.8.0-49-generic installed, " +
+			"requested by linux-image-6.8.0-49-generic, " +
+			"2 deleted libraries in use)",
+		"es": "reinicio requerido (kernel 6.8.0-49-generic " +
+			"instalado, solicitado por " +
+			"linux-image-6.8.0-49-generic, " +
+			"2 bibliotecas eliminadas en uso)",
+	}
+
+	swap(t, &Language, "")
+
+	for l, expected := range tests {
+		Language = l
+
+		if r.String() != expected {
+			t.Errorf("%s: got %q", l, r.String())
+		}
+	}
+
+	Language = "es"
+
+	if (Reboot{}).String() != "no requerido" {
+		t.Errorf("got %q", (Reboot{}).String())
+	}
+}
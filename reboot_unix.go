@@ -0,0 +1,135 @@
+//go:build !darwin && !windows
+
+package sysinfo
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/mjwhitta/pathname"
+)
+
+func (s *SysInfo) reboot() {
+	var newest string
+	var pkgs []string
+	var running string = kernelRelease()
+
+	s.Reboot = &Reboot{DeletedLibs: deletedLibs()}
+
+	// Debian and Ubuntu
+	for _, fn := range []string{
+		"/run/reboot-required",
+		"/var/run/reboot-required",
+	} {
+		if ok, _ := pathname.DoesExist(fn); ok {
+			s.Reboot.Requested = true
+			pkgs = strings.Fields(readTrim(fn + ".pkgs"))
+			break
+		}
+	}
+
+	for _, pkg := range pkgs {
+		if !slices.Contains(s.Reboot.Packages, pkg) {
+			s.Reboot.Packages = append(s.Reboot.Packages, pkg)
+		}
+	}
+
+	for _, release := range installedKernels() {
+		// Such as -generic and -lowlatency, installed side by side
+		if kernelFlavor(release) != kernelFlavor(running) {
+			continue
+		}
+
+		if (newest == "") || (compareVersions(release, newest) > 0) {
+			newest = release
+		}
+	}
+
+	if (running != "") && (newest != "") {
+		if compareVersions(newest, running) > 0 {
+			s.Reboot.Kernel = newest
+		}
+	}
+
+	s.Reboot.Required = s.Reboot.Requested ||
+		(s.Reboot.Kernel != "") ||
+		(len(s.Reboot.DeletedLibs) > 0)
+}
+
+// deletedLibs will return the shared libraries that have been
+// deleted, typically by an upgrade, but are still mapped by a
+// running process.
+func deletedLibs() []string {
+	var e error
+	var f *os.File
+	var fields []string
+	var maps []string
+	var out []string
+	var scanner *bufio.Scanner
+
+	// Only processes visible to the current user
+	maps, _ = filepath.Glob("/proc/[0-9]*/maps")
+
+	for _, fn := range maps {
+		if f, e = os.Open(filepath.Clean(fn)); e != nil {
+			continue
+		}
+
+		scanner = bufio.NewScanner(f)
+		for scanner.Scan() {
+			if !strings.HasSuffix(scanner.Text(), " (deleted)") {
+				continue
+			}
+
+			//nolint:mnd // Address, perms, offset, dev, inode, path
+			fields = strings.Fields(scanner.Text())
+			if len(fields) < 6 {
+				continue
+			}
+
+			if !strings.Contains(filepath.Base(fields[5]), ".so") {
+				continue
+			}
+
+			if !slices.Contains(out, fields[5]) {
+				out = append(out, fields[5])
+			}
+		}
+
+		_ = f.Close()
+	}
+
+	slices.Sort(out)
+
+	return out
+}
+
+// installedKernels will return the releases of the kernels installed
+// in /lib/modules or /boot.
+func installedKernels() []string {
+	var matches []string
+	var out []string
+	var release string
+
+	// Ignore leftover directories from removed kernels
+	matches, _ = filepath.Glob("/lib/modules/*/modules.dep")
+	for _, fn := range matches {
+		out = append(out, filepath.Base(filepath.Dir(fn)))
+	}
+
+	matches, _ = filepath.Glob("/boot/vmlinuz-*")
+	for _, fn := range matches {
+		release = strings.TrimPrefix(filepath.Base(fn), "vmlinuz-")
+
+		// Skip names such as vmlinuz-linux
+		if (release != "") && unicode.IsDigit(rune(release[0])) {
+			out = append(out, release)
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,30 @@
+package sysinfo
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Services is a struct containing a summary of the units managed by
+// systemd.
+type Services struct {
+	Failed  []string `json:"failed"`
+	Running int      `json:"running"`
+	Units   int      `json:"units"`
+}
+
+// String will return a string representation of the Services.
+func (s Services) String() string {
+	var out string = strconv.Itoa(s.Running) + " running, "
+
+	switch len(s.Failed) {
+	case 0:
+		return out + "no failed units"
+	case 1:
+		out += "1 failed unit"
+	default:
+		out += strconv.Itoa(len(s.Failed)) + " failed units"
+	}
+
+	return out + ": " + strings.Join(s.Failed, ", ")
+}
@@ -0,0 +1,64 @@
+//go:build linux
+
+package sysinfo
+
+// services will summarize the systemd units using the D-Bus API.
+func (s *SysInfo) services() {
+	var d *dbus
+	var e error
+	var end int
+	var r *dbusReader
+	var unit []string = make([]string, 5) //nolint:mnd // Used fields
+
+	s.Services = nil
+
+	if d, e = newDBus(); e != nil {
+		return
+	}
+	defer d.close()
+
+	r, e = d.call(
+		"org.freedesktop.systemd1",
+		"/org/freedesktop/systemd1",
+		"org.freedesktop.systemd1.Manager",
+		"ListUnits",
+	)
+	if (e != nil) || (r.bodySig != "a(ssssssouso)") {
+		return
+	}
+
+	end = int(r.uint32())
+	r.align(8) //nolint:mnd // Array of structs
+	end += r.off
+
+	s.Services = &Services{Failed: []string{}}
+
+	for !r.done(end) {
+		r.align(8) //nolint:mnd // Struct
+
+		// Name, description, load, active, and sub states
+		for i := range unit {
+			unit[i] = r.string()
+		}
+
+		// Following, unit path, job ID, job type, and job path
+		r.string()
+		r.string()
+		r.uint32()
+		r.string()
+		r.string()
+
+		if r.e != nil {
+			break
+		}
+
+		s.Services.Units++
+
+		switch {
+		case unit[3] == "failed":
+			s.Services.Failed = append(s.Services.Failed, unit[0])
+		case unit[4] == "running":
+			s.Services.Running++
+		}
+	}
+}
@@ -0,0 +1,270 @@
+//go:build linux
+
+package sysinfo
+
+import (
+	"bufio"
+	"encoding/binary"
+	"net"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// testUnit is a systemd unit, as returned by ListUnits.
+type testUnit struct {
+	name   string
+	active string
+	sub    string
+}
+
+// fakeBus will listen on a unix socket in a temporary directory and
+// point SystemBus at it. Each connection is authenticated, the Hello
+// call answered, and the next call answered with the provided reply.
+func fakeBus(t *testing.T, reply func(serial uint32) []byte) {
+	t.Helper()
+
+	var e error
+	var l net.Listener
+	var path string = filepath.Join(t.TempDir(), "bus")
+
+	if l, e = net.Listen("unix", path); e != nil {
+		t.Fatal(e)
+	}
+
+	t.Cleanup(
+		func() {
+			_ = l.Close()
+		},
+	)
+
+	swap(t, &SystemBus, "unix:path="+path)
+
+	go func() {
+		var c net.Conn
+		var e error
+
+		for {
+			if c, e = l.Accept(); e != nil {
+				return
+			}
+
+			fakeBusConn(c, reply)
+		}
+	}()
+}
+
+// fakeBusConn will serve a single client of the fake bus.
+func fakeBusConn(c net.Conn, reply func(serial uint32) []byte) {
+	var d *dbus = &dbus{conn: c, r: bufio.NewReader(c)}
+	var e error
+	var line string
+	var r *dbusReader
+
+	defer d.close()
+
+	line, e = d.r.ReadString('\n')
+	if (e != nil) || !strings.HasPrefix(line, "\x00AUTH EXTERNAL ") {
+		_, _ = c.Write([]byte("REJECTED EXTERNAL\r\n"))
+		return
+	}
+
+	_, _ = c.Write([]byte("OK 0123456789abcdef\r\n"))
+
+	line, e = d.r.ReadString('\n')
+	if (e != nil) || (line != "BEGIN\r\n") {
+		return
+	}
+
+	// Hello, followed by the NameAcquired signal
+	if r, e = d.read(); e != nil {
+		return
+	}
+
+	_, _ = c.Write(
+		testDBusMsg(
+			dbusMethodReturn,
+			testDBusSerial(r),
+			"",
+			"s",
+			dbusString(nil, ":1.1"),
+		),
+	)
+	_, _ = c.Write(
+		//nolint:mnd // Signal
+		testDBusMsg(4, 0, "", "s", dbusString(nil, ":1.1")),
+	)
+
+	if r, e = d.read(); e != nil {
+		return
+	}
+
+	_, _ = c.Write(reply(testDBusSerial(r)))
+}
+
+// testDBusMsg will return a little-endian message of the provided
+// type, with an optional reply serial, error name, and body.
+func testDBusMsg(
+	msgType byte, replySerial uint32, errName string, sig string,
+	body []byte,
+) []byte {
+	var msg []byte = []byte{'l', msgType, 0, 1}
+	var start int
+
+	msg = binary.LittleEndian.AppendUint32(msg, uint32(len(body)))
+	//nolint:mnd // Any serial
+	msg = binary.LittleEndian.AppendUint32(msg, 1000)
+	msg = append(msg, 0, 0, 0, 0)
+	start = len(msg)
+
+	if replySerial != 0 {
+		msg = append(dbusAlign(msg, 8), dbusFieldReplySerial)
+		msg = append(msg, 1, 'u', 0)
+		msg = binary.LittleEndian.AppendUint32(msg, replySerial)
+	}
+
+	if errName != "" {
+		msg = dbusField(msg, dbusFieldErrorName, "s", errName)
+	}
+
+	if sig != "" {
+		msg = dbusField(msg, dbusFieldSignature, "g", sig)
+	}
+
+	binary.LittleEndian.PutUint32(
+		msg[start-4:],
+		uint32(len(msg)-start),
+	)
+
+	return append(dbusAlign(msg, 8), body...)
+}
+
+// testDBusSerial will return the serial of the provided message.
+func testDBusSerial(r *dbusReader) uint32 {
+	return r.order.Uint32(r.b[8:])
+}
+
+// testListUnits will return a ListUnits reply body for the provided
+// units.
+func testListUnits(units []testUnit) []byte {
+	var b []byte = make([]byte, 8) // Array length and padding
+
+	for _, u := range units {
+		b = dbusAlign(b, 8)
+
+		for _, s := range []string{
+			u.name, "Description", "loaded", u.active, u.sub, "",
+			"/org/freedesktop/systemd1/unit/x",
+		} {
+			b = dbusString(dbusAlign(b, 4), s)
+		}
+
+		b = binary.LittleEndian.AppendUint32(dbusAlign(b, 4), 0)
+		b = dbusString(b, "")
+		b = dbusString(dbusAlign(b, 4), "/")
+	}
+
+	binary.LittleEndian.PutUint32(b, uint32(len(b)-8))
+
+	return b
+}
+
+func TestServices(t *testing.T) {
+	var s *SysInfo = &SysInfo{}
+	var units []testUnit = []testUnit{
+		{"a.service", "active", "running"},
+		{"b.service", "active", "running"},
+		{"c.service", "failed", "failed"},
+		{"d.socket", "active", "listening"},
+		{"e.service", "failed", "failed"},
+	}
+
+	fakeBus(
+		t,
+		func(serial uint32) []byte {
+			return testDBusMsg(
+				dbusMethodReturn,
+				serial,
+				"",
+				"a(ssssssouso)",
+				testListUnits(units),
+			)
+		},
+	)
+
+	s.services()
+
+	if s.Services == nil {
+		t.Fatal("got no services")
+	}
+
+	if s.Services.Units != len(units) {
+		t.Errorf(
+			"got %d units, want %d",
+			s.Services.Units,
+			len(units),
+		)
+	}
+
+	if s.Services.Running != 2 {
+		t.Errorf("got %d running, want 2", s.Services.Running)
+	}
+
+	if !slices.Equal(
+		s.Services.Failed,
+		[]string{"c.service", "e.service"},
+	) {
+		t.Errorf("got failed %v", s.Services.Failed)
+	}
+}
+
+func TestServicesError(t *testing.T) {
+	var s *SysInfo = &SysInfo{}
+
+	fakeBus(
+		t,
+		func(serial uint32) []byte {
+			return testDBusMsg(
+				dbusError,
+				serial,
+				"org.freedesktop.DBus.Error.ServiceUnknown",
+				"",
+				nil,
+			)
+		},
+	)
+
+	s.services()
+
+	if s.Services != nil {
+		t.Errorf("got %+v, want nil", *s.Services)
+	}
+}
+
+func TestServicesNoBus(t *testing.T) {
+	var e error
+	var s *SysInfo = &SysInfo{Services: &Services{}}
+
+	swap(
+		t,
+		&SystemBus,
+		"unix:path="+filepath.Join(t.TempDir(), "missing"),
+	)
+
+	if _, e = newDBus(); e == nil {
+		t.Error("expected error connecting to missing bus")
+	}
+
+	s.services()
+
+	if s.Services != nil {
+		t.Errorf("got %+v, want nil", *s.Services)
+	}
+
+	SystemBus = "tcp:host=localhost,port=1234"
+
+	if _, e = newDBus(); e == nil {
+		t.Error("expected error for unsupported address")
+	}
+}
@@ -0,0 +1,8 @@
+//go:build !linux
+
+package sysinfo
+
+// services is not supported on this platform.
+func (s *SysInfo) services() {
+	s.Services = nil
+}
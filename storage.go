@@ -0,0 +1,71 @@
+package sysinfo
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Storage is a struct containing the status of a software RAID
+// array, LVM volume group, ZFS pool, or btrfs filesystem.
+type Storage struct {
+	Active   int      `json:"active,omitempty"`
+	Devices  []string `json:"devices,omitempty"`
+	Errors   uint64   `json:"errors,omitempty"`
+	Level    string   `json:"level,omitempty"`
+	Name     string   `json:"name"`
+	Progress string   `json:"progress,omitempty"`
+	State    string   `json:"state"`
+	Total    int      `json:"total,omitempty"`
+	Type     string   `json:"type"`
+	Volumes  int      `json:"volumes,omitempty"`
+}
+
+// String will return a string representation of the Storage.
+func (a Storage) String() string {
+	var out []string = []string{a.Name + " (" + a.Type + ")"}
+
+	if a.Level != "" {
+		out[0] = a.Name + " (" + a.Level + ")"
+	}
+
+	out[0] += " " + a.State
+
+	if a.Total > 0 {
+		out[0] += " " + strconv.Itoa(a.Active) + "/" +
+			strconv.Itoa(a.Total)
+	}
+
+	if a.Progress != "" {
+		out = append(out, a.Progress)
+	}
+
+	if a.Errors > 0 {
+		out = append(out, strconv.FormatUint(a.Errors, 10)+" errors")
+	}
+
+	if a.Volumes > 0 {
+		out = append(out, strconv.Itoa(a.Volumes)+" LVs")
+	}
+
+	if len(a.Devices) > 0 {
+		out = append(out, strings.Join(a.Devices, " "))
+	}
+
+	return strings.Join(out, ", ")
+}
+
+// severity will return how urgently the Storage needs attention.
+func (a Storage) severity() int {
+	switch a.State {
+	case "degraded", "failed", "faulted", "suspended", "unavail":
+		return severityCrit
+	case "inactive", "offline", "removed":
+		return severityWarn
+	}
+
+	if (a.Progress != "") || (a.Errors > 0) {
+		return severityWarn
+	}
+
+	return severityOK
+}
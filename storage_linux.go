@@ -0,0 +1,264 @@
+//go:build linux
+
+package sysinfo
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+func (s *SysInfo) storage() {
+	s.Storage = nil
+	s.Storage = append(s.Storage, mdArrays()...)
+	s.Storage = append(s.Storage, lvmGroups()...)
+	s.Storage = append(s.Storage, zfsPools()...)
+	s.Storage = append(s.Storage, btrfsFilesystems()...)
+}
+
+// btrfsFilesystems will return the btrfs filesystems, along with
+// any missing devices and error counts, from sysfs.
+func btrfsFilesystems() []Storage {
+	var a Storage
+	var cols []string
+	var dirs []string
+	var entries []os.DirEntry
+	var fn string
+	var n uint64
+	var out []Storage
+
+	// Skip /sys/fs/btrfs/features
+	dirs, _ = filepath.Glob("/sys/fs/btrfs/*/devices")
+
+	for _, dir := range dirs {
+		dir = filepath.Dir(dir)
+		a = Storage{
+			Name:  readTrim(filepath.Join(dir, "label")),
+			State: "ok",
+			Type:  "btrfs",
+		}
+
+		if a.Name == "" {
+			a.Name = filepath.Base(dir)
+		}
+
+		entries, _ = os.ReadDir(filepath.Join(dir, "devices"))
+		for _, entry := range entries {
+			a.Devices = append(a.Devices, entry.Name())
+		}
+
+		entries, _ = os.ReadDir(filepath.Join(dir, "devinfo"))
+		for _, entry := range entries {
+			fn = filepath.Join(dir, "devinfo", entry.Name())
+
+			if readTrim(filepath.Join(fn, "missing")) == "1" {
+				a.State = "degraded"
+			}
+
+			// Write, read, flush, corruption, and generation errors
+			for _, line := range strings.Split(
+				readTrim(filepath.Join(fn, "error_stats")),
+				"\n",
+			) {
+				//nolint:mnd // Name and count
+				if cols = strings.Fields(line); len(cols) == 2 {
+					n, _ = strconv.ParseUint(cols[1], 10, 64)
+					a.Errors += n
+				}
+			}
+		}
+
+		out = append(out, a)
+	}
+
+	return out
+}
+
+// lvmGroups will return the active LVM volume groups, based on the
+// device mapper metadata in sysfs.
+func lvmGroups() []Storage {
+	var dirs []string
+	var entries []os.DirEntry
+	var groups map[string]*Storage = map[string]*Storage{}
+	var names []string
+	var out []Storage
+	var uuid string
+	var vg string
+
+	dirs, _ = filepath.Glob("/sys/block/dm-*")
+
+	for _, dir := range dirs {
+		uuid = readTrim(filepath.Join(dir, "dm", "uuid"))
+
+		// Skip non-LVM devices and internal layers, such as thin
+		// pool data, which have a suffix
+		if !strings.HasPrefix(uuid, "LVM-") {
+			continue
+		} else if len(uuid) > lvmUUIDLen {
+			continue
+		}
+
+		vg, _ = lvmName(readTrim(filepath.Join(dir, "dm", "name")))
+
+		if _, ok := groups[vg]; !ok {
+			groups[vg] = &Storage{
+				Name:  vg,
+				State: "active",
+				Type:  "lvm",
+			}
+			names = append(names, vg)
+		}
+
+		groups[vg].Volumes++
+
+		if readTrim(filepath.Join(dir, "dm", "suspended")) == "1" {
+			groups[vg].State = "suspended"
+		}
+
+		// Physical volumes, ignoring stacked device mapper devices
+		entries, _ = os.ReadDir(filepath.Join(dir, "slaves"))
+		for _, entry := range entries {
+			if strings.HasPrefix(entry.Name(), "dm-") {
+				continue
+			}
+
+			if !slices.Contains(groups[vg].Devices, entry.Name()) {
+				groups[vg].Devices = append(
+					groups[vg].Devices,
+					entry.Name(),
+				)
+			}
+		}
+	}
+
+	slices.Sort(names)
+
+	for _, name := range names {
+		out = append(out, *groups[name])
+	}
+
+	return out
+}
+
+// lvmName will split a device mapper name into the volume group and
+// logical volume, where any hyphens in either are doubled.
+func lvmName(name string) (string, string) {
+	for i := 0; i < len(name); i++ {
+		if name[i] != '-' {
+			continue
+		}
+
+		if (i+1 < len(name)) && (name[i+1] == '-') {
+			i++
+			continue
+		}
+
+		return strings.ReplaceAll(name[:i], "--", "-"),
+			strings.ReplaceAll(name[i+1:], "--", "-")
+	}
+
+	return strings.ReplaceAll(name, "--", "-"), ""
+}
+
+// mdArrays will return the software RAID arrays from /proc/mdstat.
+func mdArrays() []Storage {
+	var a *Storage
+	var m []string
+	var mdstat string = readTrim("/proc/mdstat")
+	var out []Storage
+
+	for _, line := range strings.Split(mdstat, "\n") {
+		if m = reMDArray.FindStringSubmatch(line); len(m) > 0 {
+			if a != nil {
+				out = append(out, *a)
+			}
+
+			a = &Storage{Name: m[1], State: m[2], Type: "md"}
+
+			for _, col := range strings.Fields(m[3]) {
+				switch {
+				case strings.HasPrefix(col, "("):
+					// Such as (auto-read-only)
+				case strings.Contains(col, "["):
+					a.Devices = append(a.Devices, mdDevice(a, col))
+				default:
+					a.Level = col
+				}
+			}
+
+			continue
+		}
+
+		if a == nil {
+			continue
+		}
+
+		if m = reMDStatus.FindStringSubmatch(line); len(m) > 0 {
+			a.Total, _ = strconv.Atoi(m[1])
+			a.Active, _ = strconv.Atoi(m[2])
+
+			if (a.Active < a.Total) && (a.State == "active") {
+				a.State = "degraded"
+			}
+		}
+
+		if m = reMDProgress.FindStringSubmatch(line); len(m) > 0 {
+			a.Progress = m[1] + " " + strings.ToLower(m[2])
+		}
+	}
+
+	if a != nil {
+		out = append(out, *a)
+	}
+
+	return out
+}
+
+// mdDevice will return the name of an md member device, such as
+// sda1[0](F), marking the array as degraded if it has failed.
+func mdDevice(a *Storage, col string) string {
+	var name string = col[:strings.Index(col, "[")]
+
+	switch {
+	case strings.HasSuffix(col, "(F)"):
+		if a.State == "active" {
+			a.State = "degraded"
+		}
+
+		return name + " (failed)"
+	case strings.HasSuffix(col, "(S)"):
+		return name + " (spare)"
+	default:
+		return name
+	}
+}
+
+// zfsPools will return the ZFS pool states from the SPL kstats.
+func zfsPools() []Storage {
+	var dir string = "/proc/spl/kstat/zfs"
+	var entries []os.DirEntry
+	var out []Storage
+	var state string
+
+	entries, _ = os.ReadDir(dir)
+
+	for _, entry := range entries {
+		state = readTrim(filepath.Join(dir, entry.Name(), "state"))
+		if state == "" {
+			continue
+		}
+
+		out = append(
+			out,
+			Storage{
+				Name:  entry.Name(),
+				State: strings.ToLower(state),
+				Type:  "zfs",
+			},
+		)
+	}
+
+	return out
+}
@@ -0,0 +1,8 @@
+//go:build !linux
+
+package sysinfo
+
+// storage is not supported on this platform.
+func (s *SysInfo) storage() {
+	s.Storage = nil
+}
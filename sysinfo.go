@@ -1,13 +1,18 @@
 package sysinfo
 
 import (
+	"context"
 	"encoding/json"
 	"net"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"runtime"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	hl "github.com/mjwhitta/hilighter"
 	"github.com/mjwhitta/where"
@@ -15,33 +20,70 @@ import (
 
 // SysInfo is a struct containing relevant system information.
 type SysInfo struct {
-	Colors string   `json:"-"`
-	CPU    string   `json:"cpu,omitempty"`
-	Height int      `json:"-"`
-	HomeFS string   `json:"homefs,omitempty"`
-	Host   string   `json:"host,omitempty"`
-	IPv4   []string `json:"ipv4,omitempty"`
-	IPv6   []string `json:"ipv6,omitempty"`
-	Kernel string   `json:"kernel,omitempty"`
-	OS     string   `json:"os,omitempty"`
-	RAM    string   `json:"ram,omitempty"`
-	RootFS string   `json:"rootfs,omitempty"`
-	Shell  string   `json:"shell,omitempty"`
-	TTY    string   `json:"tty,omitempty"`
-	Uptime string   `json:"uptime,omitempty"`
-	Width  int      `json:"-"`
-
+	Boot         string        `json:"boot,omitempty"`
+	Colors       string        `json:"-"`
+	CPU          string        `json:"cpu,omitempty"`
+	CPUHost      string        `json:"cpu_host,omitempty"`
+	DE           string        `json:"de,omitempty"`
+	Disks        []Disk        `json:"disks,omitempty"`
+	DNS          string        `json:"dns,omitempty"`
+	Firmware     string        `json:"firmware,omitempty"`
+	Gateway      []string      `json:"gateway,omitempty"`
+	GPU          []GPU         `json:"gpu,omitempty"`
+	Height       int           `json:"-"`
+	HomeFS       string        `json:"homefs,omitempty"`
+	Host         string        `json:"host,omitempty"`
+	Init         string        `json:"init,omitempty"`
+	IPv4         []string      `json:"ipv4,omitempty"`
+	IPv6         []string      `json:"ipv6,omitempty"`
+	Kernel       string        `json:"kernel,omitempty"`
+	KernelDetail *KernelDetail `json:"kernel_detail,omitempty"`
+	Model        string        `json:"model,omitempty"`
+	Net          []Interface   `json:"net,omitempty"`
+	OS           string        `json:"os,omitempty"`
+	Packages     string        `json:"packages,omitempty"`
+	Ports        []Port        `json:"ports,omitempty"`
+	PublicIP     string        `json:"public_ip,omitempty"`
+	RAM          string        `json:"ram,omitempty"`
+	RAMHost      string        `json:"ram_host,omitempty"`
+	Reboot       *Reboot       `json:"reboot,omitempty"`
+	RootFS       string        `json:"rootfs,omitempty"`
+	Serial       string        `json:"serial,omitempty"`
+	Services     *Services     `json:"services,omitempty"`
+	Session      string        `json:"session,omitempty"`
+	Shell        string        `json:"shell,omitempty"`
+	Storage      []Storage     `json:"storage,omitempty"`
+	Term         string        `json:"term,omitempty"`
+	Terminal     string        `json:"terminal,omitempty"`
+	TTY          string        `json:"tty,omitempty"`
+	Uptime       string        `json:"uptime,omitempty"`
+	User         *Identity     `json:"user,omitempty"`
+	Users        *Users        `json:"users,omitempty"`
+	Virt         string        `json:"virt,omitempty"`
+	Vulns        *Vulns        `json:"vulns,omitempty"`
+	WiFi         []Wireless    `json:"wifi,omitempty"`
+	Width        int           `json:"-"`
+	WM           string        `json:"wm,omitempty"`
+
+	critColors  []string
 	dataColors  []string
+	depth       int
 	fieldColors []string
 	ipMutex     *sync.Mutex
 	ips         map[string][]string
 	order       []string
+	warnColors  []string
 }
 
 // New will return a SysInfo pointer. A list of fields can be
 // supplied if all info is not wanted.
 func New(fields ...string) *SysInfo {
-	var s *SysInfo = &SysInfo{ipMutex: &sync.Mutex{}}
+	var s *SysInfo = &SysInfo{
+		critColors: []string{"red"},
+		depth:      colorDepth(),
+		ipMutex:    &sync.Mutex{},
+		warnColors: []string{"yellow"},
+	}
 
 	s.order = fields
 	if len(fields) == 0 {
@@ -80,38 +122,93 @@ func (s *SysInfo) calcSize() {
 
 // Clear will remove all system info.
 func (s *SysInfo) Clear() {
+	s.Boot = ""
 	s.Colors = ""
 	s.CPU = ""
+	s.CPUHost = ""
+	s.DE = ""
+	s.Disks = nil
+	s.DNS = ""
+	s.Firmware = ""
+	s.Gateway = nil
+	s.GPU = nil
 	s.HomeFS = ""
 	s.Host = ""
+	s.Init = ""
 	s.ips = nil
 	s.IPv4 = []string{}
 	s.IPv6 = []string{}
 	s.Kernel = ""
+	s.KernelDetail = nil
+	s.Model = ""
+	s.Net = nil
 	s.OS = ""
+	s.Packages = ""
+	s.Ports = nil
+	s.PublicIP = ""
 	s.RAM = ""
+	s.RAMHost = ""
+	s.Reboot = nil
 	s.RootFS = ""
+	s.Serial = ""
+	s.Services = nil
+	s.Session = ""
 	s.Shell = ""
+	s.Storage = nil
+	s.Term = ""
+	s.Terminal = ""
 	s.TTY = ""
 	s.Uptime = ""
+	s.User = nil
+	s.Users = nil
+	s.Virt = ""
+	s.Vulns = nil
+	s.WiFi = nil
+	s.WM = ""
 	s.calcSize()
 }
 
 // Collect will get requested system info.
 func (s *SysInfo) Collect() {
 	var collectFuncs map[string]func() = map[string]func(){
-		"blank":  nil,
-		"colors": s.colors,
-		"cpu":    s.cpu,
-		"fs":     s.filesystems,
-		"host":   s.hostname,
-		"ip":     s.ipAddresses,
-		"kernel": s.kernel,
-		"os":     s.operatingSystem,
-		"ram":    s.ram,
-		"shell":  s.shell,
-		"tty":    s.tty,
-		"uptime": s.uptime,
+		"blank":         nil,
+		"boot":          s.boot,
+		"colors":        s.colors,
+		"cpu":           s.cpu,
+		"de":            s.desktop,
+		"disks":         s.disks,
+		"dns":           s.dns,
+		"firmware":      s.firmware,
+		"fs":            s.filesystems,
+		"gateway":       s.gateway,
+		"gpu":           s.gpu,
+		"host":          s.hostname,
+		"init":          s.initSystem,
+		"ip":            s.ipAddresses,
+		"kernel":        s.kernel,
+		"kernel:detail": s.kernelDetail,
+		"model":         s.model,
+		"net":           s.network,
+		"os":            s.operatingSystem,
+		"packages":      s.packages,
+		"ports":         s.ports,
+		"public_ip":     s.publicIP,
+		"ram":           s.ram,
+		"reboot":        s.reboot,
+		"services":      s.services,
+		"session":       s.session,
+		"shell":         s.shell,
+		"storage":       s.storage,
+		"term":          s.term,
+		"terminal":      s.terminal,
+		"tty":           s.tty,
+		"uptime":        s.uptime,
+		"user":          s.user,
+		"users":         s.users,
+		"virt":          s.virt,
+		"vulns":         s.vulns,
+		"wifi":          s.wifi,
+		"wm":            s.windowManager,
 	}
 	var newOrder []string
 	var wg sync.WaitGroup
@@ -142,14 +239,32 @@ func (s *SysInfo) Collect() {
 }
 
 func (s *SysInfo) exec(cmd string, cli ...string) string {
+	return s.execTimeout(0, cmd, cli...)
+}
+
+func (s *SysInfo) execTimeout(
+	timeout time.Duration, cmd string, cli ...string,
+) string {
+	var cancel context.CancelFunc
+	var ctx context.Context = context.Background()
 	var e error
 	var o []byte
 
-	if (cmd == "") || (where.Is(cmd) == "") {
+	if cmd == "" {
 		return ""
 	}
 
-	if o, e = exec.Command(cmd, cli...).Output(); e != nil {
+	if !filepath.IsAbs(cmd) && (where.Is(cmd) == "") {
+		return ""
+	}
+
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	o, e = exec.CommandContext(ctx, cmd, cli...).Output()
+	if e != nil {
 		return ""
 	}
 
@@ -157,13 +272,29 @@ func (s *SysInfo) exec(cmd string, cli ...string) string {
 }
 
 func (s *SysInfo) format(k string, v string, maxWidth int) string {
-	var filler string = strings.Repeat(" ", maxWidth-len(k)+1)
+	return s.formatColors(k, v, maxWidth, s.dataColors)
+}
+
+// formatColors will format the field like format, but using the
+// provided colors for the data, such as warnColors.
+func (s *SysInfo) formatColors(
+	k string, v string, maxWidth int, colors []string,
+) string {
+	var filler string = strings.Repeat(
+		" ",
+		max(maxWidth-len([]rune(k)), 0)+1,
+	)
 	var sb strings.Builder
 
+	// Only for display, JSON is left as is
+	if v == "unknown" {
+		v = label(v)
+	}
+
 	sb.WriteString(filler)
-	sb.WriteString(hl.Hilights(s.fieldColors, k+":"))
+	sb.WriteString(hl.Hilights(s.supported(s.fieldColors), k+":"))
 	sb.WriteString(" ")
-	sb.WriteString(hl.Hilights(s.dataColors, v))
+	sb.WriteString(hl.Hilights(s.supported(colors), v))
 
 	return sb.String()
 }
@@ -205,7 +336,7 @@ func (s *SysInfo) getIPs() map[string][]string {
 				continue
 			}
 
-			if strings.HasPrefix(iface.Name, "docker") {
+			if !keepInterface(iface.Name) {
 				continue
 			}
 
@@ -273,6 +404,20 @@ func (s *SysInfo) ipv6() {
 	sort.Strings(s.IPv6)
 }
 
+// SetColorDepth will override the number of colors supported by the
+// terminal (0, 16, 256, or 16777216), which is normally detected
+// from COLORTERM and TERM.
+func (s *SysInfo) SetColorDepth(depth int) {
+	s.depth = depth
+}
+
+// SetCritColors will set the color values for field data that needs
+// urgent attention, such as a degraded RAID array. See
+// github.com/mjwhitta/hilighter for valid colors.
+func (s *SysInfo) SetCritColors(colors ...string) {
+	s.critColors = colors
+}
+
 // SetDataColors will set the color values for the field data. See
 // github.com/mjwhitta/hilighter for valid colors.
 func (s *SysInfo) SetDataColors(colors ...string) {
@@ -285,6 +430,69 @@ func (s *SysInfo) SetFieldColors(colors ...string) {
 	s.fieldColors = colors
 }
 
+// SetWarnColors will set the color values for field data that may
+// need attention, such as a resyncing RAID array. See
+// github.com/mjwhitta/hilighter for valid colors.
+func (s *SysInfo) SetWarnColors(colors ...string) {
+	s.warnColors = colors
+}
+
+// severityColors will return the colors for field data with the
+// provided severity.
+func (s *SysInfo) severityColors(severity int) []string {
+	switch severity {
+	case severityCrit:
+		return s.critColors
+	case severityWarn:
+		return s.warnColors
+	default:
+		return s.dataColors
+	}
+}
+
+// shellVersion will return the version of the specified shell. Only
+// shells that are known to support --version are executed.
+func (s *SysInfo) shellVersion(name string, exe string) string {
+	if exe == "" {
+		exe = name
+	}
+
+	switch name {
+	case "bash", "fish", "nu", "tcsh", "zsh":
+	default:
+		return ""
+	}
+
+	return reVersion.FindString(
+		s.execTimeout(time.Second, exe, "--version"),
+	)
+}
+
+// supported will filter out any colors that the terminal can't
+// display. Modes (bold, underline, etc) are always kept.
+func (s *SysInfo) supported(codes []string) []string {
+	var out []string
+
+	if s.depth >= colors256 {
+		return codes
+	}
+
+	for _, code := range codes {
+		switch {
+		case s.depth == 0:
+			if _, ok := hl.Modes[normalizeColor(code)]; !ok {
+				continue
+			}
+		case reExtendedColor.MatchString(normalizeColor(code)):
+			continue
+		}
+
+		out = append(out, code)
+	}
+
+	return out
+}
+
 // String will return a string representation of the SysInfo.
 func (s *SysInfo) String() string {
 	var data map[string]string = map[string]string{}
@@ -295,9 +503,10 @@ func (s *SysInfo) String() string {
 	tmp, _ = json.Marshal(s)
 	_ = json.Unmarshal(tmp, &data)
 
+	// Ignore JSON-only fields, as they are never displayed
 	for k := range data {
-		if len(k) > maxWidth {
-			maxWidth = len(k)
+		if _, ok := titleCase[k]; ok {
+			maxWidth = max(maxWidth, len([]rune(label(k))))
 		}
 	}
 
@@ -306,15 +515,26 @@ func (s *SysInfo) String() string {
 		case "blank":
 			out = append(out, "")
 		case "colors":
-			if s.Colors != "" {
-				out = append(out, " "+s.Colors)
+			if s.Colors == "" {
+				continue
+			}
+
+			for _, line := range strings.Split(s.Colors, "\n") {
+				out = append(out, " "+line)
+			}
+		case "disks":
+			for _, d := range s.Disks {
+				out = append(
+					out,
+					s.format(label(field), d.String(), maxWidth),
+				)
 			}
 		case "fs":
 			field = "rootfs"
 			if _, ok := data[field]; ok {
 				out = append(
 					out,
-					s.format(titleCase[field], data[field], maxWidth),
+					s.format(label(field), data[field], maxWidth),
 				)
 			}
 
@@ -322,7 +542,150 @@ func (s *SysInfo) String() string {
 			if _, ok := data[field]; ok {
 				out = append(
 					out,
-					s.format(titleCase[field], data[field], maxWidth),
+					s.format(label(field), data[field], maxWidth),
+				)
+			}
+		case "gateway":
+			for _, gw := range s.Gateway {
+				out = append(
+					out,
+					s.format(label(field), gw, maxWidth),
+				)
+			}
+		case "kernel:detail":
+			if s.KernelDetail == nil {
+				continue
+			}
+
+			field = "kernel"
+			out = append(
+				out,
+				s.format(
+					label(field),
+					joinNonEmpty(
+						" ",
+						s.KernelDetail.Release,
+						s.KernelDetail.Arch,
+					),
+					maxWidth,
+				),
+			)
+
+			for _, line := range strings.Split(
+				s.KernelDetail.String(),
+				"\n",
+			) {
+				if line != "" {
+					out = append(
+						out,
+						s.format(label(field), line, maxWidth),
+					)
+				}
+			}
+		case "gpu":
+			for _, g := range s.GPU {
+				out = append(
+					out,
+					s.format(label(field), g.String(), maxWidth),
+				)
+			}
+		case "net":
+			for _, iface := range s.Net {
+				out = append(
+					out,
+					s.format(
+						label(field),
+						iface.String(),
+						maxWidth,
+					),
+				)
+			}
+		case "ports":
+			if len(s.Ports) > 0 {
+				out = append(
+					out,
+					s.format(
+						label(field),
+						formatPorts(s.Ports),
+						maxWidth,
+					),
+				)
+			}
+		case "reboot":
+			if s.Reboot != nil {
+				out = append(
+					out,
+					s.format(
+						label(field),
+						s.Reboot.String(),
+						maxWidth,
+					),
+				)
+			}
+		case "services":
+			if s.Services != nil {
+				out = append(
+					out,
+					s.format(
+						label(field),
+						s.Services.String(),
+						maxWidth,
+					),
+				)
+			}
+		case "storage":
+			for _, a := range s.Storage {
+				out = append(
+					out,
+					s.formatColors(
+						label(field),
+						a.String(),
+						maxWidth,
+						s.severityColors(a.severity()),
+					),
+				)
+			}
+		case "user":
+			if s.User != nil {
+				out = append(
+					out,
+					s.format(
+						label(field),
+						s.User.String(),
+						maxWidth,
+					),
+				)
+			}
+		case "users":
+			if s.Users == nil {
+				continue
+			}
+
+			for _, line := range strings.Split(
+				s.Users.String(),
+				"\n",
+			) {
+				out = append(
+					out,
+					s.format(label(field), line, maxWidth),
+				)
+			}
+		case "vulns":
+			if s.Vulns != nil {
+				out = append(
+					out,
+					s.format(
+						label(field),
+						s.Vulns.String(),
+						maxWidth,
+					),
+				)
+			}
+		case "wifi":
+			for _, w := range s.WiFi {
+				out = append(
+					out,
+					s.format(label(field), w.String(), maxWidth),
 				)
 			}
 		case "ip":
@@ -331,7 +694,7 @@ func (s *SysInfo) String() string {
 			for _, ip := range s.IPv4 {
 				out = append(
 					out,
-					s.format(titleCase[field], ip, maxWidth),
+					s.format(label(field), ip, maxWidth),
 				)
 			}
 
@@ -340,14 +703,14 @@ func (s *SysInfo) String() string {
 			for _, ip := range s.IPv6 {
 				out = append(
 					out,
-					s.format(titleCase[field], ip, maxWidth),
+					s.format(label(field), ip, maxWidth),
 				)
 			}
 		default:
 			if _, ok := data[field]; ok {
 				out = append(
 					out,
-					s.format(titleCase[field], data[field], maxWidth),
+					s.format(label(field), data[field], maxWidth),
 				)
 			}
 		}
@@ -355,3 +718,160 @@ func (s *SysInfo) String() string {
 
 	return strings.Join(out, "\n")
 }
+
+func (s *SysInfo) term() {
+	var out []string = []string{os.Getenv("TERM")}
+
+	if cols, rows, ok := termSize(s.ttyDevice()); ok {
+		out = append(out, strconv.Itoa(cols)+"x"+strconv.Itoa(rows))
+	}
+
+	switch s.depth {
+	case 0:
+		out = append(out, "no color")
+	case colors16:
+		out = append(out, "16 colors")
+	case colors256:
+		out = append(out, "256 colors")
+	default:
+		out = append(out, "truecolor")
+	}
+
+	if IsTerminal(os.Stdin) && IsTerminal(os.Stdout) {
+		out = append(out, "interactive")
+	} else {
+		out = append(out, "non-interactive")
+	}
+
+	s.Term = joinNonEmpty(", ", out...)
+}
+
+// appendPackages will append a package count for the specified
+// package manager, if any packages were found.
+func appendPackages(out []string, count int, mgr string) []string {
+	if count > 0 {
+		out = append(out, strconv.Itoa(count)+" ("+mgr+")")
+	}
+
+	return out
+}
+
+// colorDepth will return the number of colors supported by the
+// terminal, based on COLORTERM and TERM.
+func colorDepth() int {
+	var term string = strings.ToLower(os.Getenv("TERM"))
+
+	switch strings.ToLower(os.Getenv("COLORTERM")) {
+	case "24bit", "truecolor":
+		return colorsTrue
+	}
+
+	switch {
+	case os.Getenv("WT_SESSION") != "":
+		// Windows Terminal doesn't set COLORTERM
+		return colorsTrue
+	case strings.Contains(term, "256color"):
+		return colors256
+	case term == "dumb":
+		return 0
+	case (term == "") && (runtime.GOOS != "windows"):
+		return 0
+	}
+
+	return colors16
+}
+
+// countDirs will count the non-hidden subdirectories in each of the
+// specified directories.
+func countDirs(dirs ...string) int {
+	var count int
+	var e error
+	var entries []os.DirEntry
+
+	for _, dir := range dirs {
+		if entries, e = os.ReadDir(dir); e != nil {
+			continue
+		}
+
+		for _, entry := range entries {
+			if strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+
+			if entry.IsDir() {
+				count++
+			}
+		}
+	}
+
+	return count
+}
+
+// hypervisor will return the name of the first known hypervisor that
+// matches any of the provided vendor or product strings.
+func hypervisor(vals ...string) string {
+	for _, val := range vals {
+		val = strings.ToLower(val)
+
+		for _, hv := range hypervisors {
+			if strings.Contains(val, hv[0]) {
+				return hv[1]
+			}
+		}
+	}
+
+	return ""
+}
+
+// joinNonEmpty will join the provided values with the specified
+// separator, skipping any empty values.
+func joinNonEmpty(sep string, vals ...string) string {
+	var out []string
+
+	for _, val := range vals {
+		if val = strings.TrimSpace(val); val != "" {
+			out = append(out, val)
+		}
+	}
+
+	return strings.Join(out, sep)
+}
+
+// normalizeColor will normalize a color the same way hilighter does.
+func normalizeColor(code string) string {
+	code = strings.ReplaceAll(code, "_", "")
+	code = strings.ReplaceAll(code, "-", "")
+
+	return strings.ToLower(code)
+}
+
+// terminalFromEnv will attempt to identify the terminal emulator or
+// multiplexer using well-known environment variables.
+func terminalFromEnv() string {
+	switch {
+	case os.Getenv("TMUX") != "":
+		return "tmux"
+	case os.Getenv("STY") != "":
+		return "screen"
+	case os.Getenv("WT_SESSION") != "":
+		return "Windows Terminal"
+	}
+
+	switch term := os.Getenv("TERM_PROGRAM"); term {
+	case "Apple_Terminal":
+		return "Terminal"
+	case "iTerm.app":
+		return "iTerm2"
+	case "vscode":
+		return "VS Code"
+	case "":
+	default:
+		return term
+	}
+
+	if os.Getenv("SSH_CONNECTION") != "" {
+		return "SSH"
+	}
+
+	return ""
+}
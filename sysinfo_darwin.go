@@ -3,31 +3,20 @@
 package sysinfo
 
 import (
-	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
-
-	hl "github.com/mjwhitta/hilighter"
+	"time"
 )
 
+// bootTime will return the system boot time.
+func (s *SysInfo) bootTime() time.Time {
+	return parseBootTime(s.exec("sysctl", "-n", "kern.boottime"))
+}
+
 func (s *SysInfo) colors() {
-	s.Colors = strings.Join(
-		[]string{
-			hl.Hilights([]string{"light_black", "on_black"}, "▄▄▄"),
-			hl.Hilights([]string{"light_red", "on_red"}, "▄▄▄"),
-			hl.Hilights([]string{"light_green", "on_green"}, "▄▄▄"),
-			hl.Hilights([]string{"light_yellow", "on_yellow"}, "▄▄▄"),
-			hl.Hilights([]string{"light_blue", "on_blue"}, "▄▄▄"),
-			hl.Hilights(
-				[]string{"light_magenta", "on_magenta"},
-				"▄▄▄",
-			),
-			hl.Hilights([]string{"light_cyan", "on_cyan"}, "▄▄▄"),
-			hl.Hilights([]string{"light_white", "on_white"}, "▄▄▄"),
-		},
-		"",
-	)
+	s.Colors = s.palette()
 }
 
 func (s *SysInfo) cpu() {
@@ -36,6 +25,18 @@ func (s *SysInfo) cpu() {
 	s.CPU = reWhiteSpace.ReplaceAllString(s.CPU, " ")
 }
 
+func (s *SysInfo) desktop() {
+	s.DE = "Aqua"
+}
+
+func (s *SysInfo) dns() {
+	var search []string
+	var servers []string
+
+	servers, search = resolvConf("/etc/resolv.conf")
+	s.DNS = formatDNS(servers, search, false)
+}
+
 func (s *SysInfo) filesystems() {
 	s.RootFS = s.fsUsage("/")
 
@@ -48,108 +49,282 @@ func (s *SysInfo) filesystems() {
 	}
 }
 
+func (s *SysInfo) firmware() {
+	var cols []string
+
+	s.Firmware = ""
+
+	for _, line := range strings.Split(s.hardware(), "\n") {
+		cols = strings.SplitN(strings.TrimSpace(line), ":", 2)
+
+		//nolint:mnd // Key: value == 2 fields
+		if (len(cols) == 2) && (cols[0] == "System Firmware Version") {
+			s.Firmware = strings.TrimSpace(cols[1])
+		}
+	}
+}
+
 func (s *SysInfo) fsUsage(path string) string {
 	var cols []string
-	var usage string = s.exec("df", "-h", path)
+	var e error
+	var size uint64
+	var usage string = s.exec("df", "-P", "-k", path)
+	var used uint64
 
 	for _, line := range strings.Split(usage, "\n") {
 		cols = strings.Fields(line)
 
 		//nolint:mnd // Validate output format
-		if (len(cols) == 9) && (cols[8] == path) {
-			return cols[2] + " / " + cols[1] + " (" + cols[4] + ")"
+		if (len(cols) != 6) || (cols[5] != path) {
+			continue
+		}
+
+		if size, e = strconv.ParseUint(cols[1], 10, 64); e != nil {
+			return ""
+		}
+
+		if used, e = strconv.ParseUint(cols[2], 10, 64); e != nil {
+			return ""
 		}
+
+		//nolint:mnd // In 1024-byte blocks
+		return formatUsage(used*1024, size*1024, cols[4])
 	}
 
 	return ""
 }
 
+func (s *SysInfo) gateway() {
+	var cols []string
+	var gw string
+	var iface string
+
+	s.Gateway = nil
+
+	for _, family := range []string{"-inet", "-inet6"} {
+		gw = ""
+		iface = ""
+
+		for _, line := range strings.Split(
+			s.exec("route", "-n", "get", family, "default"),
+			"\n",
+		) {
+			cols = strings.Fields(line)
+
+			//nolint:mnd // Key: value == 2 fields
+			if len(cols) != 2 {
+				continue
+			}
+
+			switch cols[0] {
+			case "gateway:":
+				gw = cols[1]
+			case "interface:":
+				iface = cols[1]
+			}
+		}
+
+		if gw != "" {
+			s.Gateway = append(s.Gateway, gw+" ("+iface+")")
+		}
+	}
+}
+
+func (s *SysInfo) hardware() string {
+	return s.exec("system_profiler", "SPHardwareDataType")
+}
+
+func (s *SysInfo) initSystem() {
+	s.Init = "launchd"
+}
+
 func (s *SysInfo) kernel() {
 	s.Kernel = s.exec("sysctl", "-n", "kern.osrelease")
 }
 
+func (s *SysInfo) kernelDetail() {
+	s.KernelDetail = &KernelDetail{
+		Arch:    s.exec("uname", "-m"),
+		Build:   s.exec("sysctl", "-n", "kern.version"),
+		Release: s.exec("sysctl", "-n", "kern.osrelease"),
+	}
+}
+
+func (s *SysInfo) model() {
+	var cols []string
+
+	if s.Model = s.exec("sysctl", "-n", "hw.model"); s.Model == "" {
+		s.Model = "unknown"
+	}
+
+	if !ShowSerials {
+		return
+	}
+
+	for _, line := range strings.Split(s.hardware(), "\n") {
+		cols = strings.SplitN(strings.TrimSpace(line), ":", 2)
+
+		//nolint:mnd // Key: value == 2 fields
+		if (len(cols) == 2) && (cols[0] == "Serial Number (system)") {
+			s.Serial = strings.TrimSpace(cols[1])
+		}
+	}
+}
+
 func (s *SysInfo) operatingSystem() {
 	s.OS = s.exec("uname", "-m", "-s")
 }
 
+func (s *SysInfo) packages() {
+	var out []string
+
+	out = appendPackages(
+		out,
+		countDirs(
+			"/opt/homebrew/Cellar",
+			"/opt/homebrew/Caskroom",
+			"/usr/local/Cellar",
+			"/usr/local/Caskroom",
+		),
+		"brew",
+	)
+	out = appendPackages(
+		out,
+		countDirs("/opt/local/var/macports/software"),
+		"port",
+	)
+
+	s.Packages = strings.Join(out, ", ")
+}
+
+func (s *SysInfo) ports() {
+	s.Ports = nil
+}
+
 func (s *SysInfo) ram() {
 	var e error
-	var mb int = 1024 * 1024
-	var phys int
+	var phys uint64
 	var tmp string
-	var total int
-	var user int
+	var total uint64
+	var user uint64
 
 	s.RAM = "unknown"
 
 	tmp = s.exec("sysctl", "-n", "hw.physmem")
-	if phys, e = strconv.Atoi(tmp); e != nil {
+	if phys, e = strconv.ParseUint(tmp, 10, 64); e != nil {
 		return
 	}
 
 	tmp = s.exec("sysctl", "-n", "hw.usermem")
-	if user, e = strconv.Atoi(tmp); e != nil {
+	if user, e = strconv.ParseUint(tmp, 10, 64); e != nil {
 		return
 	}
 
 	tmp = s.exec("sysctl", "-n", "hw.memsize")
-	if total, e = strconv.Atoi(tmp); e != nil {
+	if total, e = strconv.ParseUint(tmp, 10, 64); e != nil {
 		return
 	}
 
-	s.RAM = fmt.Sprintf("%d MB / %d MB", (phys+user)/mb, total/mb)
+	s.RAM = formatUsage(phys+user, total, "")
+}
+
+func (s *SysInfo) reboot() {
+	s.Reboot = nil
+}
+
+func (s *SysInfo) session() {
+	s.Session = "Quartz"
 }
 
 func (s *SysInfo) shell() {
+	var exe string
+	var name string
+	var ppid string = strconv.Itoa(os.Getppid())
+
 	s.Shell = "unknown"
 
-	if sh, ok := os.LookupEnv("SHELL"); ok {
-		s.Shell = strings.TrimSpace(sh)
+	// Login shells are prefixed with a dash
+	exe = s.exec("ps", "-o", "comm=", "-p", ppid)
+	exe = strings.TrimPrefix(exe, "-")
+
+	if name = filepath.Base(exe); !shells[name] {
+		// Fall back to the login shell
+		if exe = strings.TrimSpace(os.Getenv("SHELL")); exe == "" {
+			return
+		}
+
+		name = filepath.Base(exe)
+	}
+
+	if !filepath.IsAbs(exe) {
+		exe = name
 	}
+
+	s.Shell = joinNonEmpty(" ", name, s.shellVersion(name, exe))
+}
+
+func (s *SysInfo) terminal() {
+	s.Terminal = terminalFromEnv()
 }
 
 func (s *SysInfo) tty() {
-	// There's probably a better way
-	s.TTY = os.Getenv("GPG_TTY")
-	if s.TTY = strings.TrimSpace(s.TTY); s.TTY == "" {
+	if s.TTY = s.ttyDevice(); s.TTY == "" {
 		s.TTY = "unknown"
 	}
 }
 
-func (s *SysInfo) uptime() {
-	var uptime string
+func (s *SysInfo) ttyDevice() string {
+	// There's probably a better way
+	return strings.TrimSpace(os.Getenv("GPG_TTY"))
+}
 
-	s.Uptime = "0 mins"
+// uptimeDuration will return the time since boot.
+func (s *SysInfo) uptimeDuration() time.Duration {
+	var t time.Time
 
-	// Fail fast
-	if uptime = s.exec("uptime"); uptime == "" {
-		return
+	if t = s.bootTime(); t.IsZero() {
+		return 0
 	}
 
-	// Strip extra whitespace
-	uptime = reWhiteSpace.ReplaceAllString(uptime, " ")
+	return time.Since(t)
+}
 
-	// Strip leading and trailing data
-	uptime = reUptimeEnds.ReplaceAllString(uptime, "")
+func (s *SysInfo) virt() {
+	s.Virt = "none"
 
-	// Make plural, if not already
-	if strings.HasSuffix(uptime, "min") {
-		uptime += "s"
+	if s.exec("sysctl", "-n", "kern.hv_vmm_present") == "1" {
+		s.Virt = "Unknown hypervisor guest"
 	}
+}
+
+func (s *SysInfo) vulns() {
+	s.Vulns = nil
+}
+
+func (s *SysInfo) windowManager() {
+	s.WM = "Quartz Compositor"
+}
 
-	// Convert hours:mins to match days
-	uptime = reHrMin.ReplaceAllString(uptime, "$1 hours, $2 mins")
+// bootReason is not supported on this platform.
+func bootReason() string {
+	return ""
+}
 
-	// Remove 0 hours and mins
-	uptime = reZeroHrsMins.ReplaceAllString(uptime, "")
+// capabilities is not supported on this platform.
+func capabilities() ([]string, bool) {
+	return nil, false
+}
 
-	// Make singular, if needed
-	uptime = reOneHrMin.ReplaceAllString(uptime, "${1}1 ${2}")
+// interfaceDetails is not supported on this platform. Only the
+// details provided by the net package are available.
+func interfaceDetails(_ *Interface) {}
 
-	// Remove leading and trailing commas or whitespace
-	uptime = reCommaSpace.ReplaceAllString(uptime, "")
+// lastLogin is not supported on this platform.
+func lastLogin(_ string, _ []Login) *Login {
+	return nil
+}
 
-	if uptime != "" {
-		s.Uptime = uptime
-	}
+// logins is not supported on this platform.
+func logins() []Login {
+	return []Login{}
 }
@@ -0,0 +1,19 @@
+package sysinfo
+
+import "testing"
+
+// swap will set the provided global to the specified value and
+// restore the original value when the test completes.
+func swap[T any](t *testing.T, global *T, val T) {
+	t.Helper()
+
+	var orig T = *global
+
+	*global = val
+
+	t.Cleanup(
+		func() {
+			*global = orig
+		},
+	)
+}
@@ -3,36 +3,39 @@
 package sysinfo
 
 import (
-	"bytes"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
-	hl "github.com/mjwhitta/hilighter"
 	"github.com/mjwhitta/pathname"
 )
 
+// bootTime will return the system boot time from /proc/stat, falling
+// back to the kern.boottime sysctl on the BSDs.
+func (s *SysInfo) bootTime() time.Time {
+	var e error
+	var sec int64
+
+	for _, line := range strings.Split(readTrim("/proc/stat"), "\n") {
+		if tmp, ok := strings.CutPrefix(line, "btime "); ok {
+			if sec, e = strconv.ParseInt(tmp, 10, 64); e == nil {
+				return time.Unix(sec, 0)
+			}
+		}
+	}
+
+	return parseBootTime(s.exec("sysctl", "-n", "kern.boottime"))
+}
+
 func (s *SysInfo) colors() {
-	s.Colors = strings.Join(
-		[]string{
-			hl.Hilights([]string{"light_black", "on_black"}, "▄▄▄"),
-			hl.Hilights([]string{"light_red", "on_red"}, "▄▄▄"),
-			hl.Hilights([]string{"light_green", "on_green"}, "▄▄▄"),
-			hl.Hilights([]string{"light_yellow", "on_yellow"}, "▄▄▄"),
-			hl.Hilights([]string{"light_blue", "on_blue"}, "▄▄▄"),
-			hl.Hilights(
-				[]string{"light_magenta", "on_magenta"},
-				"▄▄▄",
-			),
-			hl.Hilights([]string{"light_cyan", "on_cyan"}, "▄▄▄"),
-			hl.Hilights([]string{"light_white", "on_white"}, "▄▄▄"),
-		},
-		"",
-	)
+	s.Colors = s.palette()
 }
 
 func (s *SysInfo) cpu() {
+	var brand string
 	var e error
 	var info []byte
 	var m [][]string
@@ -44,13 +47,22 @@ func (s *SysInfo) cpu() {
 	}
 
 	m = reModelName.FindAllStringSubmatch(string(info), -1)
-	if len(m) > 0 {
+	if len(m) == 0 {
+		return
+	}
+
+	brand = reCPUBrand.ReplaceAllString(m[0][2], "")
+	brand = reWhiteSpace.ReplaceAllString(brand, " ")
+	s.CPU = fmt.Sprintf("%s(x%d)", brand, len(m))
+
+	// Report the cgroup limit, if it's less than the host
+	if cpus, ok := cgroupCPUs(); ok && (cpus < float64(len(m))) {
+		s.CPUHost = s.CPU
 		s.CPU = fmt.Sprintf(
-			"%s(x%d)",
-			reCPUBrand.ReplaceAllString(m[0][2], ""),
-			len(m),
+			"%s(x%s) (cgroup)",
+			brand,
+			strconv.FormatFloat(cpus, 'f', -1, 64),
 		)
-		s.CPU = reWhiteSpace.ReplaceAllString(s.CPU, " ")
 	}
 }
 
@@ -68,28 +80,37 @@ func (s *SysInfo) filesystems() {
 
 func (s *SysInfo) fsUsage(path string) string {
 	var cols []string
-	var usage string = s.exec("df", "-h", path)
+	var e error
+	var size uint64
+	var usage string = s.exec("df", "-P", "-k", path)
+	var used uint64
 
 	for _, line := range strings.Split(usage, "\n") {
 		cols = strings.Fields(line)
 
 		//nolint:mnd // Validate output format
-		if (len(cols) == 6) && (cols[5] == path) {
-			return cols[2] + " / " + cols[1] + " (" + cols[4] + ")"
+		if (len(cols) != 6) || (cols[5] != path) {
+			continue
+		}
+
+		if size, e = strconv.ParseUint(cols[1], 10, 64); e != nil {
+			return ""
 		}
+
+		if used, e = strconv.ParseUint(cols[2], 10, 64); e != nil {
+			return ""
+		}
+
+		//nolint:mnd // In 1024-byte blocks
+		return formatUsage(used*1024, size*1024, cols[4])
 	}
 
 	return ""
 }
 
 func (s *SysInfo) kernel() {
-	var b []byte
-
-	s.Kernel = "unknown"
-
-	b, _ = os.ReadFile("/proc/sys/kernel/osrelease")
-	if b = bytes.TrimSpace(b); len(b) > 0 {
-		s.Kernel = string(b)
+	if s.Kernel = kernelRelease(); s.Kernel == "" {
+		s.Kernel = "unknown"
 	}
 }
 
@@ -113,73 +134,160 @@ func (s *SysInfo) operatingSystem() {
 }
 
 func (s *SysInfo) ram() {
+	var avail uint64
+	var cl int
+	var cu int
 	var m [][]string
-	var mb int = 1024
-	var total int
-	var used int
+	var mem map[string]uint64 = memInfo()
+	var ok bool
+	var total uint64
+	var used uint64
 
 	s.RAM = "unknown"
 
-	m = reRAM.FindAllStringSubmatch(s.exec("free"), -1)
-	if len(m) > 0 {
+	// Prefer the kernel's own estimate, falling back to free
+	avail, ok = mem["MemAvailable"]
+	if !ok || (avail > mem["MemTotal"]) {
+		m = reRAM.FindAllStringSubmatch(s.exec("free"), -1)
+	}
+
+	switch {
+	case len(m) > 0:
 		// No need to check the errors here b/c the regex capture
 		// group has to be an int
-		total, _ = strconv.Atoi(m[0][1])
-		used, _ = strconv.Atoi(m[0][2])
+		total, _ = strconv.ParseUint(m[0][1], 10, 64)
+		used, _ = strconv.ParseUint(m[0][2], 10, 64)
+
+		//nolint:mnd // In KiB
+		total, used = total*1024, used*1024
+	case ok:
+		total = mem["MemTotal"]
+		used = total - avail
+	}
 
-		s.RAM = fmt.Sprintf("%d MB / %d MB", used/mb, total/mb)
+	if total > 0 {
+		s.RAM = formatUsage(used, total, "")
+	}
+
+	// Report the cgroup limit, if it's less than the host or the host
+	// total is unknown
+	cu, cl, ok = cgroupMemory()
+	if ok && ((total == 0) || (uint64(cl) < total)) {
+		if total > 0 {
+			s.RAMHost = s.RAM
+		}
+
+		s.RAM = formatUsage(uint64(cu), uint64(cl), "") + " (cgroup)"
 	}
 }
 
 func (s *SysInfo) shell() {
+	var exe string
+	var name string
+
 	s.Shell = "unknown"
 
-	if sh, ok := os.LookupEnv("SHELL"); ok {
-		s.Shell = strings.TrimSpace(sh)
+	// Find the nearest ancestor that is a shell
+	for _, pid := range procTree(strconv.Itoa(os.Getppid())) {
+		if name = procComm(pid); shells[name] {
+			exe = procExe(pid)
+			break
+		}
+
+		name = ""
+	}
+
+	// Fall back to the login shell
+	if name == "" {
+		if exe = strings.TrimSpace(os.Getenv("SHELL")); exe == "" {
+			return
+		}
+
+		name = filepath.Base(exe)
+	}
+
+	s.Shell = joinNonEmpty(" ", name, s.shellVersion(name, exe))
+}
+
+func (s *SysInfo) terminal() {
+	s.Terminal = ""
+
+	for _, pid := range procTree(strconv.Itoa(os.Getppid())) {
+		if term, ok := terminals[procComm(pid)]; ok {
+			s.Terminal = term
+			return
+		}
 	}
+
+	s.Terminal = terminalFromEnv()
 }
 
 func (s *SysInfo) tty() {
-	s.TTY = "unknown"
-	if tty, e := os.Readlink("/proc/self/fd/0"); e == nil {
-		s.TTY = strings.TrimSpace(tty)
+	if s.TTY = s.ttyDevice(); s.TTY == "" {
+		s.TTY = "unknown"
 	}
 }
 
-func (s *SysInfo) uptime() {
-	var uptime string
+func (s *SysInfo) ttyDevice() string {
+	var tty string
 
-	s.Uptime = "0 mins"
+	tty, _ = os.Readlink("/proc/self/fd/0")
 
-	// Fail fast
-	if uptime = s.exec("uptime"); uptime == "" {
-		return
-	}
+	return strings.TrimSpace(tty)
+}
 
-	// Strip extra whitespace
-	uptime = reWhiteSpace.ReplaceAllString(uptime, " ")
+// uptimeDuration will return the time since boot from /proc/uptime,
+// falling back to the boot time.
+func (s *SysInfo) uptimeDuration() time.Duration {
+	var cols []string = strings.Fields(readTrim("/proc/uptime"))
+	var t time.Time
 
-	// Strip leading and trailing data
-	uptime = reUptimeEnds.ReplaceAllString(uptime, "")
+	if len(cols) > 0 {
+		if secs, e := strconv.ParseFloat(cols[0], 64); e == nil {
+			return time.Duration(secs * float64(time.Second))
+		}
+	}
 
-	// Make plural, if not already
-	if strings.HasSuffix(uptime, "min") {
-		uptime += "s"
+	if t = s.bootTime(); !t.IsZero() {
+		return time.Since(t)
 	}
 
-	// Convert hours:mins to match days
-	uptime = reHrMin.ReplaceAllString(uptime, "$1 hours, $2 mins")
+	return 0
+}
+
+// kernelRelease will return the release of the running kernel.
+func kernelRelease() string {
+	return readTrim("/proc/sys/kernel/osrelease")
+}
 
-	// Remove 0 hours and mins
-	uptime = reZeroHrsMins.ReplaceAllString(uptime, "")
+// memInfo will return the values from /proc/meminfo, in bytes.
+func memInfo() map[string]uint64 {
+	var e error
+	var fields []string
+	var lines []string
+	var mem map[string]uint64 = map[string]uint64{}
+	var n uint64
+
+	lines = strings.Split(readTrim("/proc/meminfo"), "\n")
+
+	for _, line := range lines {
+		// Such as "MemTotal:       16318496 kB"
+		//nolint:mnd // Key and value
+		if fields = strings.Fields(line); len(fields) < 2 {
+			continue
+		}
 
-	// Make singular, if needed
-	uptime = reOneHrMin.ReplaceAllString(uptime, "${1}1 ${2}")
+		if n, e = strconv.ParseUint(fields[1], 10, 64); e != nil {
+			continue
+		}
 
-	// Remove leading and trailing commas or whitespace
-	uptime = reCommaSpace.ReplaceAllString(uptime, "")
+		//nolint:mnd // In KiB
+		if (len(fields) > 2) && (fields[2] == "kB") {
+			n *= 1024
+		}
 
-	if uptime != "" {
-		s.Uptime = uptime
+		mem[strings.TrimSuffix(fields[0], ":")] = n
 	}
+
+	return mem
 }
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"golang.org/x/sys/windows/registry"
 )
@@ -26,11 +27,70 @@ type objectAttrs struct {
 	SecurityQualityOfService uintptr
 }
 
+// colors is not supported on this platform. hilighter strips color
+// codes on Windows, even when the terminal supports VT sequences
+// (such as Windows Terminal), so palettes would only be uncolored
+// glyphs.
 func (s *SysInfo) colors() {
-	// Needs hilighter support
 	s.Colors = ""
 }
 
+func (s *SysInfo) bios() map[string]string {
+	var bios map[string]string = map[string]string{}
+	var e error
+	var k registry.Key
+
+	k, e = registry.OpenKey(
+		registry.LOCAL_MACHINE,
+		filepath.Join("Hardware", "Description", "System", "BIOS"),
+		registry.QUERY_VALUE,
+	)
+	if e != nil {
+		return bios
+	}
+	defer func() {
+		_ = k.Close()
+	}()
+
+	for _, name := range []string{
+		"BaseBoardManufacturer",
+		"BaseBoardProduct",
+		"BIOSReleaseDate",
+		"BIOSVendor",
+		"BIOSVersion",
+		"SystemManufacturer",
+		"SystemProductName",
+		"SystemVersion",
+	} {
+		if val, _, e := k.GetStringValue(name); e == nil {
+			bios[name] = strings.TrimSpace(val)
+		}
+	}
+
+	return bios
+}
+
+// bootTime will return the system boot time.
+func (s *SysInfo) bootTime() time.Time {
+	var e error
+	var t time.Time
+
+	t, e = time.Parse(
+		time.RFC3339Nano,
+		s.exec(
+			"powershell",
+			"-c",
+			"(gcim win32_operatingsystem).lastbootuptime"+
+				".touniversaltime().tostring(\"o\")",
+		),
+	)
+	if e != nil {
+		return time.Time{}
+	}
+
+	return t
+}
+
 func (s *SysInfo) cpu() {
 	var cpu string
 	var e error
@@ -64,6 +124,39 @@ func (s *SysInfo) cpu() {
 	s.CPU = reWhiteSpace.ReplaceAllString(s.CPU, " ")
 }
 
+func (s *SysInfo) desktop() {
+	s.DE = ""
+}
+
+func (s *SysInfo) dns() {
+	var search []string
+	var servers []string
+
+	servers = strings.Fields(
+		s.exec(
+			"powershell",
+			"-c",
+			strings.Join(
+				[]string{
+					"get-dnsclientserveraddress",
+					"select -expand serveraddresses",
+					"sort -unique",
+				},
+				"|",
+			),
+		),
+	)
+	search = strings.Fields(
+		s.exec(
+			"powershell",
+			"-c",
+			"(get-dnsclientglobalsetting).suffixsearchlist",
+		),
+	)
+
+	s.DNS = formatDNS(servers, search, false)
+}
+
 func (s *SysInfo) filesystems() {
 	var home string = strings.ToLower(os.Getenv("HOMEDRIVE"))
 
@@ -76,6 +169,20 @@ func (s *SysInfo) filesystems() {
 	}
 }
 
+func (s *SysInfo) firmware() {
+	var bios map[string]string = s.bios()
+
+	s.Firmware = joinNonEmpty(
+		" ",
+		bios["BIOSVendor"],
+		bios["BIOSVersion"],
+	)
+
+	if (s.Firmware != "") && (bios["BIOSReleaseDate"] != "") {
+		s.Firmware += " (" + bios["BIOSReleaseDate"] + ")"
+	}
+}
+
 func (s *SysInfo) fsUsage(path string) string {
 	var cmds []string = []string{
 		fmt.Sprintf(
@@ -86,15 +193,13 @@ func (s *SysInfo) fsUsage(path string) string {
 	}
 	var cols []string
 	var e error
-	var free int
-	var mb int = 1024 * 1024 * 1024
-	var total int
+	var free uint64
+	var total uint64
 	var usage string = s.exec(
 		"powershell",
 		"-c",
 		strings.Join(cmds, "|"),
 	)
-	var used int
 
 	path = strings.ToLower(path)
 
@@ -103,23 +208,21 @@ func (s *SysInfo) fsUsage(path string) string {
 
 		//nolint:mnd // Validate output format
 		if (len(cols) == 3) && (cols[0] == path) {
-			if free, e = strconv.Atoi(cols[1]); e != nil {
-				return s.RAM
+			free, e = strconv.ParseUint(cols[1], 10, 64)
+			if e != nil {
+				return ""
 			}
 
-			if total, e = strconv.Atoi(cols[2]); e != nil {
-				return s.RAM
+			total, e = strconv.ParseUint(cols[2], 10, 64)
+			if (e != nil) || (total == 0) {
+				return ""
 			}
 
-			free /= mb
-			total /= mb
-			used = total - free
-
-			return fmt.Sprintf(
-				"%dG / %dG (%d%%)",
-				used,
+			//nolint:mnd // Percentage
+			return formatUsage(
+				total-free,
 				total,
-				100*used/total,
+				strconv.FormatUint(100*(total-free)/total, 10)+"%",
 			)
 		}
 	}
@@ -127,47 +230,79 @@ func (s *SysInfo) fsUsage(path string) string {
 	return ""
 }
 
-func (s *SysInfo) kernel() {
-	var build string
-	var e error
-	var k registry.Key
-	var kernel string
-	var minor uint64
+func (s *SysInfo) gateway() {
+	var cmds []string = []string{
+		"get-netroute -destinationprefix 0.0.0.0/0,::/0",
+		"%{$_.nexthop + \" \" + $_.interfacealias}",
+	}
+	var cols []string
 
-	s.Kernel = "unknown"
+	s.Gateway = nil
 
-	k, e = registry.OpenKey(
-		registry.LOCAL_MACHINE,
-		filepath.Join(
-			"Software",
-			"Microsoft",
-			"Windows NT",
-			"CurrentVersion",
-		),
-		registry.QUERY_VALUE,
-	)
-	if e != nil {
-		return
+	for _, line := range strings.Split(
+		s.exec("powershell", "-c", strings.Join(cmds, "|")),
+		"\n",
+	) {
+		line = strings.TrimSpace(line)
+
+		//nolint:mnd // Gateway and interface alias
+		if cols = strings.SplitN(line, " ", 2); len(cols) == 2 {
+			s.Gateway = append(s.Gateway, cols[0]+" ("+cols[1]+")")
+		}
 	}
-	defer func() {
-		_ = k.Close()
-	}()
+}
 
-	if kernel, _, e = k.GetStringValue("DisplayVersion"); e != nil {
-		return
+func (s *SysInfo) initSystem() {
+	s.Init = ""
+}
+
+func (s *SysInfo) kernel() {
+	if s.Kernel = kernelRelease(); s.Kernel == "" {
+		s.Kernel = "unknown"
 	}
+}
 
-	if build, _, e = k.GetStringValue("CurrentBuild"); e != nil {
-		return
+func (s *SysInfo) kernelDetail() {
+	s.KernelDetail = &KernelDetail{
+		Arch:    strings.ToLower(os.Getenv("PROCESSOR_ARCHITECTURE")),
+		Release: kernelRelease(),
 	}
+}
 
-	s.Kernel = kernel + " (OS Build " + build
+func (s *SysInfo) model() {
+	var bios map[string]string = s.bios()
+	var product string = bios["SystemProductName"]
+	var vendor string = bios["SystemManufacturer"]
+	var version string = bios["SystemVersion"]
 
-	if minor, _, e = k.GetIntegerValue("UBR"); e == nil {
-		s.Kernel += fmt.Sprintf(".%d", minor)
+	s.Model = "unknown"
+
+	if ShowSerials {
+		s.Serial = s.exec(
+			"powershell",
+			"-c",
+			"(gcim win32_bios).serialnumber",
+		)
 	}
 
-	s.Kernel += ")"
+	// Fall back to the board when the product is not populated
+	if product == "" {
+		product = bios["BaseBoardProduct"]
+		vendor = bios["BaseBoardManufacturer"]
+		version = ""
+	}
+
+	if strings.HasPrefix(product, vendor) {
+		vendor = ""
+	}
+
+	if strings.Contains(product, version) {
+		version = ""
+	}
+
+	if tmp := joinNonEmpty(" ", vendor, product, version); tmp != "" {
+		s.Model = tmp
+	}
 }
 
 func (s *SysInfo) operatingSystem() {
@@ -201,13 +336,43 @@ func (s *SysInfo) operatingSystem() {
 	s.OS = os
 }
 
+func (s *SysInfo) packages() {
+	var choco string = os.Getenv("ChocolateyInstall")
+	var out []string
+	var scoop string = os.Getenv("SCOOP")
+
+	if choco == "" {
+		choco = filepath.Join(os.Getenv("ProgramData"), "chocolatey")
+	}
+
+	if scoop == "" {
+		scoop = filepath.Join(os.Getenv("USERPROFILE"), "scoop")
+	}
+
+	out = appendPackages(
+		out,
+		countDirs(filepath.Join(choco, "lib")),
+		"choco",
+	)
+
+	// Scoop installs itself as an app
+	if n := countDirs(filepath.Join(scoop, "apps")); n > 0 {
+		out = appendPackages(out, n-1, "scoop")
+	}
+
+	s.Packages = strings.Join(out, ", ")
+}
+
+func (s *SysInfo) ports() {
+	s.Ports = nil
+}
+
 func (s *SysInfo) ram() {
 	var cmds []string
 	var e error
-	var free int
-	var mb int = 1024 * 1024
+	var free uint64
 	var out string
-	var total int
+	var total uint64
 
 	s.RAM = "unknown"
 
@@ -222,7 +387,7 @@ func (s *SysInfo) ram() {
 		strings.Join(cmds, "|"),
 	)
 
-	if free, e = strconv.Atoi(out); e != nil {
+	if free, e = strconv.ParseUint(out, 10, 64); e != nil {
 		return
 	}
 
@@ -237,11 +402,43 @@ func (s *SysInfo) ram() {
 		strings.Join(cmds, "|"),
 	)
 
-	if total, e = strconv.Atoi(out); e != nil {
+	if total, e = strconv.ParseUint(out, 10, 64); e != nil {
 		return
 	}
 
-	s.RAM = fmt.Sprintf("%d MB / %d MB", (total-free)/mb, total/mb)
+	s.RAM = formatUsage(total-free, total, "")
+}
+
+func (s *SysInfo) reboot() {
+	var e error
+	var k registry.Key
+
+	s.Reboot = &Reboot{}
+
+	// Only exists while Windows Update is waiting on a reboot
+	k, e = registry.OpenKey(
+		registry.LOCAL_MACHINE,
+		filepath.Join(
+			"Software",
+			"Microsoft",
+			"Windows",
+			"CurrentVersion",
+			"WindowsUpdate",
+			"Auto Update",
+			"RebootRequired",
+		),
+		registry.QUERY_VALUE,
+	)
+	if e == nil {
+		_ = k.Close()
+		s.Reboot.Requested = true
+	}
+
+	s.Reboot.Required = s.Reboot.Requested
+}
+
+func (s *SysInfo) session() {
+	s.Session = ""
 }
 
 func (s *SysInfo) shell() {
@@ -259,56 +456,117 @@ func (s *SysInfo) shell() {
 	}
 }
 
+func (s *SysInfo) terminal() {
+	s.Terminal = terminalFromEnv()
+}
+
 func (s *SysInfo) tty() {
-	s.TTY = ""
+	s.TTY = s.ttyDevice()
 }
 
-func (s *SysInfo) uptime() {
-	var out string = s.exec(
-		"powershell",
-		"-c",
-		"(date) - (gcim win32_operatingsystem).lastbootuptime",
+func (s *SysInfo) ttyDevice() string {
+	return ""
+}
+
+// uptimeDuration will return the time since boot.
+func (s *SysInfo) uptimeDuration() time.Duration {
+	var t time.Time
+
+	if t = s.bootTime(); t.IsZero() {
+		return 0
+	}
+
+	return time.Since(t)
+}
+
+func (s *SysInfo) virt() {
+	var bios map[string]string = s.bios()
+
+	s.Virt = hypervisor(
+		bios["SystemManufacturer"],
+		bios["SystemProductName"],
+		bios["BaseBoardManufacturer"],
+		bios["BIOSVendor"],
 	)
-	var stop bool
-	var unit string
-
-	for _, line := range strings.Split(out, "\n") {
-		unit = ""
-
-		switch {
-		case strings.HasPrefix(line, "Days"):
-			unit = "day"
-		case strings.HasPrefix(line, "Hours"):
-			unit = "hour"
-		case strings.HasPrefix(line, "Minutes"):
-			unit = "min"
-			stop = true
-		}
 
-		if unit == "" {
-			continue
-		}
+	if s.Virt == "" {
+		s.Virt = "none"
+	} else {
+		s.Virt += " guest"
+	}
+}
 
-		//nolint:mnd // Unit : value == 3 fields
-		if tmp := strings.Fields(line); len(tmp) == 3 {
-			if tmp[2] != "0" {
-				if s.Uptime != "" {
-					s.Uptime += ", "
-				}
-
-				s.Uptime += tmp[2] + " " + unit
-				if tmp[2] != "1" {
-					s.Uptime += "s"
-				}
-			}
-		}
+func (s *SysInfo) vulns() {
+	s.Vulns = nil
+}
 
-		if stop {
-			break
-		}
+func (s *SysInfo) windowManager() {
+	s.WM = "DWM"
+}
+
+// bootReason is not supported on this platform.
+func bootReason() string {
+	return ""
+}
+
+// capabilities is not supported on this platform.
+func capabilities() ([]string, bool) {
+	return nil, false
+}
+
+// interfaceDetails is not supported on this platform. Only the
+// details provided by the net package are available.
+func interfaceDetails(_ *Interface) {}
+
+// kernelRelease will return the Windows version and OS build.
+func kernelRelease() string {
+	var build string
+	var e error
+	var k registry.Key
+	var kernel string
+	var minor uint64
+	var out string
+
+	k, e = registry.OpenKey(
+		registry.LOCAL_MACHINE,
+		filepath.Join(
+			"Software",
+			"Microsoft",
+			"Windows NT",
+			"CurrentVersion",
+		),
+		registry.QUERY_VALUE,
+	)
+	if e != nil {
+		return ""
+	}
+	defer func() {
+		_ = k.Close()
+	}()
+
+	if kernel, _, e = k.GetStringValue("DisplayVersion"); e != nil {
+		return ""
+	}
+
+	if build, _, e = k.GetStringValue("CurrentBuild"); e != nil {
+		return ""
 	}
 
-	if s.Uptime == "" {
-		s.Uptime = "0 mins"
+	out = kernel + " (OS Build " + build
+
+	if minor, _, e = k.GetIntegerValue("UBR"); e == nil {
+		out += fmt.Sprintf(".%d", minor)
 	}
+
+	return out + ")"
+}
+
+// lastLogin is not supported on this platform.
+func lastLogin(_ string, _ []Login) *Login {
+	return nil
+}
+
+// logins is not supported on this platform.
+func logins() []Login {
+	return []Login{}
 }
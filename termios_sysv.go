@@ -0,0 +1,9 @@
+//go:build aix || linux || solaris || zos
+
+package sysinfo
+
+import "golang.org/x/sys/unix"
+
+// ioctlGetTermios is the request used to read terminal attributes.
+// It's untyped as the request is an int on some platforms.
+const ioctlGetTermios = unix.TCGETS
@@ -0,0 +1,89 @@
+package sysinfo
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// fixedUnit will return the base and exponent of the specified unit,
+// or -1 if it is not a known unit.
+func fixedUnit(unit string) (float64, int) {
+	for _, u := range [][]string{unitsIEC, unitsShort} {
+		for i := range u {
+			if u[i] == unit {
+				return 1024, i //nolint:mnd // 1024 per unit
+			}
+		}
+	}
+
+	for i := range unitsSI {
+		if unitsSI[i] == unit {
+			return 1000, i //nolint:mnd // 1000 per unit
+		}
+	}
+
+	return 0, -1
+}
+
+// formatBytes will convert the provided number of bytes to a string
+// using the configured ByteUnits and BytePrecision.
+//
+//nolint:mnd // 1024 or 1000 per unit
+func formatBytes(b uint64) string {
+	var base float64 = 1024
+	var i int
+	var sep string = " "
+	var units []string = unitsIEC
+	var val float64 = float64(b)
+
+	switch strings.ToLower(ByteUnits) {
+	case "auto":
+		// Compact, like df -h
+		sep = ""
+		units = unitsShort
+	case "iec":
+	case "si":
+		base = 1000
+		units = unitsSI
+	default:
+		if base, i = fixedUnit(ByteUnits); i >= 0 {
+			val /= math.Pow(base, float64(i))
+
+			return formatFloat(val, i) + " " + ByteUnits
+		}
+
+		// Invalid units, fallback to IEC
+		base = 1024
+		i = 0
+	}
+
+	for (val >= base) && (i < len(units)-1) {
+		val /= base
+		i++
+	}
+
+	return formatFloat(val, i) + sep + units[i]
+}
+
+// formatFloat will format the provided value with BytePrecision
+// decimal places, unless it is a whole number of bytes.
+func formatFloat(val float64, exp int) string {
+	if exp == 0 {
+		return strconv.FormatFloat(val, 'f', 0, 64)
+	}
+
+	return strconv.FormatFloat(val, 'f', max(BytePrecision, 0), 64)
+}
+
+// formatUsage will return the used and total bytes, along with the
+// percentage used, if provided.
+func formatUsage(used uint64, total uint64, pct string) string {
+	var out string = formatBytes(used) + " / " + formatBytes(total)
+
+	if pct != "" {
+		out += " (" + pct + ")"
+	}
+
+	return out
+}
@@ -0,0 +1,134 @@
+package sysinfo
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+func (s *SysInfo) boot() {
+	var reason string
+	var t time.Time
+
+	s.Boot = ""
+
+	if t = s.bootTime(); t.IsZero() {
+		return
+	}
+
+	s.Boot = t.Local().Format("2006-01-02 15:04:05 MST")
+
+	if reason = bootReason(); reason != "" {
+		s.Boot += " (after " + reason + ")"
+	}
+}
+
+func (s *SysInfo) uptime() {
+	s.Uptime = formatUptime(s.uptimeDuration())
+}
+
+// formatUptime will return the provided duration in the configured
+// UptimeStyle.
+//
+//nolint:mnd // Time conversions
+func formatUptime(d time.Duration) string {
+	var out []string
+	var parts []int = []int{
+		int(d.Hours()) / 24,
+		int(d.Hours()) % 24,
+		int(d.Minutes()) % 60,
+	}
+
+	switch strings.ToLower(UptimeStyle) {
+	case "iso8601":
+		// Precise, so include seconds
+		return isoDuration(d)
+	case "short":
+		for i, unit := range []string{"d", "h", "m"} {
+			if parts[i] > 0 {
+				out = append(out, strconv.Itoa(parts[i])+unit)
+			}
+		}
+
+		if len(out) == 0 {
+			return "0m"
+		}
+
+		return strings.Join(out, " ")
+	default:
+		for i, unit := range []string{"day", "hour", "min"} {
+			if parts[i] > 0 {
+				out = append(out, plural(parts[i], unit))
+			}
+		}
+
+		if len(out) == 0 {
+			return plural(0, "min")
+		}
+
+		return strings.Join(out, ", ")
+	}
+}
+
+// isoDuration will return the provided duration in ISO 8601 format,
+// such as P3DT4H5M6S.
+//
+//nolint:mnd // Time conversions
+func isoDuration(d time.Duration) string {
+	var days int = int(d.Hours()) / 24
+	var out string = "P"
+	var parts []int = []int{
+		int(d.Hours()) % 24,
+		int(d.Minutes()) % 60,
+		int(d.Seconds()) % 60,
+	}
+	var t string
+
+	if days > 0 {
+		out += strconv.Itoa(days) + "D"
+	}
+
+	for i, unit := range []string{"H", "M", "S"} {
+		if parts[i] > 0 {
+			t += strconv.Itoa(parts[i]) + unit
+		}
+	}
+
+	if t != "" {
+		out += "T" + t
+	}
+
+	if out == "P" {
+		return "PT0S"
+	}
+
+	return out
+}
+
+// parseBootTime will parse the output of the kern.boottime sysctl,
+// such as "{ sec = 1700000000, usec = 0 } Tue Nov 14 22:13:20 2023".
+func parseBootTime(out string) time.Time {
+	var e error
+	var m []string
+	var sec int64
+
+	if m = reBootTime.FindStringSubmatch(out); len(m) < 2 {
+		return time.Time{}
+	}
+
+	if sec, e = strconv.ParseInt(m[1], 10, 64); e != nil {
+		return time.Time{}
+	}
+
+	return time.Unix(sec, 0)
+}
+
+// plural will return the count followed by the localized unit,
+// pluralized if needed.
+func plural(n int, unit string) string {
+	if n == 1 {
+		return "1 " + label(unit)
+	}
+
+	return strconv.Itoa(n) + " " + label(unit+"s")
+}
@@ -0,0 +1,84 @@
+package sysinfo
+
+import (
+	"os"
+	"os/user"
+	"strings"
+)
+
+// Identity is a struct containing details about the user running
+// the current process.
+type Identity struct {
+	Capabilities []string `json:"capabilities,omitempty"`
+	GID          string   `json:"gid"`
+	Group        string   `json:"group,omitempty"`
+	Groups       []string `json:"groups,omitempty"`
+	Home         string   `json:"home,omitempty"`
+	Restricted   bool     `json:"restricted,omitempty"`
+	Root         bool     `json:"root"`
+	UID          string   `json:"uid"`
+	Username     string   `json:"username"`
+}
+
+// String will return a string representation of the Identity.
+func (i Identity) String() string {
+	var out string = joinNonEmpty(
+		" ",
+		i.Username+" ("+i.UID+")",
+		strings.Join(i.Groups, ","),
+	)
+
+	switch {
+	case i.Root && i.Restricted:
+		out += ", root (restricted)"
+	case i.Root:
+		out += ", root"
+	case len(i.Capabilities) > 0:
+		out += ", caps " + strings.Join(i.Capabilities, ",")
+	}
+
+	return out
+}
+
+func (s *SysInfo) user() {
+	var e error
+	var gids []string
+	var missing bool
+	var u *user.User
+
+	s.User = &Identity{UID: "unknown", Username: "unknown"}
+
+	if u, e = user.Current(); e != nil {
+		return
+	}
+
+	s.User.GID = u.Gid
+	s.User.Group = groupName(u.Gid)
+	s.User.Home = u.HomeDir
+	s.User.UID = u.Uid
+	s.User.Username = u.Username
+
+	// Only supplementary groups
+	gids, _ = u.GroupIds()
+	for _, gid := range gids {
+		if gid != u.Gid {
+			s.User.Groups = append(s.User.Groups, groupName(gid))
+		}
+	}
+
+	s.User.Root = os.Geteuid() == 0
+	s.User.Capabilities, missing = capabilities()
+
+	// Typically root inside a container
+	s.User.Restricted = s.User.Root && missing
+}
+
+// groupName will return the name of the specified group, or the ID
+// if it has no name.
+func groupName(gid string) string {
+	if g, e := user.LookupGroupId(gid); e == nil {
+		return g.Name
+	}
+
+	return gid
+}
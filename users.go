@@ -0,0 +1,124 @@
+package sysinfo
+
+import (
+	"os/user"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Login is a struct containing details about a login session.
+type Login struct {
+	Host string    `json:"host,omitempty"`
+	PID  int       `json:"pid,omitempty"`
+	Time time.Time `json:"time"`
+	TTY  string    `json:"tty"`
+	User string    `json:"user"`
+}
+
+// String will return a string representation of the Login.
+func (l Login) String() string {
+	var out string = l.User + " " + l.TTY
+
+	if l.Host != "" {
+		out += " (" + l.Host + ")"
+	}
+
+	return out
+}
+
+// Users is a struct containing details about the logged-in users and
+// the identity of the current user.
+type Users struct {
+	GID       string   `json:"gid"`
+	Groups    []string `json:"groups,omitempty"`
+	LastLogin *Login   `json:"last_login,omitempty"`
+	Logins    []Login  `json:"logins"`
+	UID       string   `json:"uid"`
+	Username  string   `json:"username"`
+}
+
+// String will return a string representation of the Users, one line
+// per detail.
+func (u Users) String() string {
+	var logins []string
+	var out []string
+	var tmp string = "1 user"
+
+	if len(u.Logins) != 1 {
+		tmp = strconv.Itoa(len(u.Logins)) + " users"
+	}
+
+	for _, l := range u.Logins {
+		logins = append(logins, l.String())
+	}
+
+	if len(logins) > 0 {
+		tmp += ": " + strings.Join(logins, ", ")
+	}
+
+	out = append(out, tmp)
+
+	tmp = "uid=" + u.UID + "(" + u.Username + ")"
+	if u.GID != "" {
+		tmp += " gid=" + u.GID
+	}
+
+	if len(u.Groups) > 0 {
+		tmp += " groups=" + strings.Join(u.Groups, ",")
+	}
+
+	out = append(out, tmp)
+
+	if u.LastLogin != nil {
+		out = append(
+			out,
+			"last login "+u.LastLogin.String()+" "+
+				u.LastLogin.Time.Format("Mon Jan 2 15:04"),
+		)
+	}
+
+	return strings.Join(out, "\n")
+}
+
+func (s *SysInfo) users() {
+	var e error
+	var gids []string
+	var u *user.User
+
+	s.Users = &Users{Logins: logins()}
+
+	if u, e = user.Current(); e != nil {
+		s.Users.UID = "unknown"
+		s.Users.Username = "unknown"
+		return
+	}
+
+	s.Users.GID = u.Gid
+	s.Users.UID = u.Uid
+	s.Users.Username = u.Username
+
+	s.Users.GID += "(" + groupName(u.Gid) + ")"
+
+	gids, _ = u.GroupIds()
+	for _, gid := range gids {
+		s.Users.Groups = append(
+			s.Users.Groups,
+			gid+"("+groupName(gid)+")",
+		)
+	}
+
+	s.Users.LastLogin = lastLogin(u.Username, s.Users.Logins)
+}
+
+// isCurrentLogin will return whether or not the Login is one of the
+// current logins.
+func isCurrentLogin(l Login, current []Login) bool {
+	for _, c := range current {
+		if (c.TTY == l.TTY) && c.Time.Equal(l.Time) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,184 @@
+//go:build !darwin && !windows
+
+package sysinfo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/mjwhitta/pathname"
+)
+
+// utmp is the glibc/musl utmp record, see utmp(5).
+type utmp struct {
+	Type int16
+	_    [2]byte // Padding
+	PID  int32
+	Line [32]byte
+	_    [4]byte // ID
+	User [32]byte
+	Host [256]byte
+	_    [8]byte // Exit status and session ID
+	Sec  int32
+	Usec int32
+	Addr [4]uint32
+	_    [20]byte // Reserved
+}
+
+// login will convert the utmp record to a Login.
+func (u utmp) login() Login {
+	var host string = string(bytes.TrimRight(u.Host[:], "\x00"))
+
+	// Fall back to the address, if no hostname was recorded
+	if (host == "") && (u.Addr != [4]uint32{}) {
+		if (u.Addr[1] | u.Addr[2] | u.Addr[3]) == 0 {
+			host = net.IP(
+				binary.NativeEndian.AppendUint32(nil, u.Addr[0]),
+			).String()
+		}
+	}
+
+	return Login{
+		Host: host,
+		PID:  int(u.PID),
+		Time: time.Unix(int64(u.Sec), int64(u.Usec)*1000),
+		TTY:  string(bytes.TrimRight(u.Line[:], "\x00")),
+		User: string(bytes.TrimRight(u.User[:], "\x00")),
+	}
+}
+
+// bootReason will return how the previous boot ended, based on the
+// records in wtmp.
+func bootReason() string {
+	var boots int
+	var out string
+
+	wtmp(
+		func(rec utmp) bool {
+			var name []byte = bytes.TrimRight(rec.User[:], "\x00")
+
+			switch {
+			case rec.Type == utmpBootTime:
+				// No shutdown between the previous and current boot
+				if boots++; boots > 1 {
+					out = "unclean shutdown"
+					return false
+				}
+			case (boots == 1) && (rec.Type == utmpRunLevel):
+				if string(name) == "shutdown" {
+					out = "clean shutdown"
+					return false
+				}
+			}
+
+			return true
+		},
+	)
+
+	return out
+}
+
+// lastLogin will return the most recent login for the specified user
+// from wtmp, ignoring any current logins.
+func lastLogin(name string, current []Login) *Login {
+	var out *Login
+
+	wtmp(
+		func(rec utmp) bool {
+			var l Login
+
+			if rec.Type != utmpUserProcess {
+				return true
+			}
+
+			if l = rec.login(); l.User != name {
+				return true
+			}
+
+			if isCurrentLogin(l, current) {
+				return true
+			}
+
+			out = &l
+
+			return false
+		},
+	)
+
+	return out
+}
+
+// logins will return the current login sessions from utmp.
+func logins() []Login {
+	var b []byte
+	var e error
+	var out []Login = []Login{}
+	var pid string
+	var r *bytes.Reader
+	var rec utmp
+
+	for _, fn := range []string{"/run/utmp", "/var/run/utmp"} {
+		if b, e = os.ReadFile(filepath.Clean(fn)); e == nil {
+			break
+		}
+	}
+
+	r = bytes.NewReader(b)
+	for binary.Read(r, binary.NativeEndian, &rec) == nil {
+		if rec.Type != utmpUserProcess {
+			continue
+		}
+
+		// Skip stale entries left behind by crashed sessions
+		pid = filepath.Join("/proc", strconv.Itoa(int(rec.PID)))
+		if ok, _ := pathname.DoesExist(pid); !ok {
+			continue
+		}
+
+		out = append(out, rec.login())
+	}
+
+	return out
+}
+
+// wtmp will call the provided function for each record in wtmp,
+// newest first, until it returns false.
+func wtmp(f func(rec utmp) bool) {
+	var e error
+	var fh *os.File
+	var offset int64
+	var rec utmp
+	var size int64 = int64(binary.Size(utmp{}))
+
+	if fh, e = os.Open("/var/log/wtmp"); e != nil {
+		return
+	}
+	defer func() {
+		_ = fh.Close()
+	}()
+
+	if offset, e = fh.Seek(0, io.SeekEnd); e != nil {
+		return
+	}
+
+	// Newest records are at the end
+	for offset -= offset % size; offset >= size; offset -= size {
+		if _, e = fh.Seek(offset-size, io.SeekStart); e != nil {
+			return
+		}
+
+		if e = binary.Read(fh, binary.NativeEndian, &rec); e != nil {
+			return
+		}
+
+		if !f(rec) {
+			return
+		}
+	}
+}
@@ -0,0 +1,125 @@
+//go:build !darwin && !windows
+
+package sysinfo
+
+import (
+	"bytes"
+	"os"
+	"strings"
+
+	"github.com/mjwhitta/pathname"
+)
+
+func (s *SysInfo) virt() {
+	s.Virt = joinNonEmpty(", ", containerType(), hypervisorType())
+	if s.Virt == "" {
+		s.Virt = "none"
+	}
+}
+
+// containerType will return the type of container, if any, the
+// current process is running in.
+func containerType() string {
+	var b []byte
+	var e error
+	var prefix []byte = []byte("container=")
+	var release string = readTrim("/proc/sys/kernel/osrelease")
+
+	// WSL is closer to a container than a traditional guest
+	switch {
+	case strings.Contains(release, "WSL2"):
+		return "WSL2"
+	case strings.Contains(strings.ToLower(release), "microsoft"):
+		return "WSL"
+	}
+
+	// Set by systemd, podman, lxc, and others for PID 1
+	if b, e = os.ReadFile("/proc/1/environ"); e == nil {
+		for _, env := range bytes.Split(b, []byte{0}) {
+			if tmp, ok := bytes.CutPrefix(env, prefix); ok {
+				return containerName(string(tmp))
+			}
+		}
+	}
+
+	if tmp := readTrim("/run/systemd/container"); tmp != "" {
+		return containerName(tmp)
+	}
+
+	if ok, _ := pathname.DoesExist("/run/.containerenv"); ok {
+		return "Podman container"
+	}
+
+	if ok, _ := pathname.DoesExist("/.dockerenv"); ok {
+		return "Docker container"
+	}
+
+	// Only useful with cgroup v1 or a non-private cgroup namespace
+	if tmp := readTrim("/proc/1/cgroup"); tmp != "" {
+		for _, c := range containers {
+			if strings.Contains(tmp, c[0]) {
+				return c[1]
+			}
+		}
+	}
+
+	if ok, _ := pathname.DoesExist("/proc/vz"); ok {
+		if ok, _ = pathname.DoesExist("/proc/bc"); !ok {
+			return "OpenVZ container"
+		}
+	}
+
+	return ""
+}
+
+// containerName will convert a container identifier to a friendly
+// name.
+func containerName(id string) string {
+	for _, c := range containers {
+		if strings.HasPrefix(id, c[0]) {
+			return c[1]
+		}
+	}
+
+	return id + " container"
+}
+
+// hypervisorType will return the hypervisor, if any, the current
+// system is running on.
+func hypervisorType() string {
+	var hv string = hypervisor(
+		dmi("sys_vendor"),
+		dmi("product_name"),
+		dmi("board_vendor"),
+		dmi("bios_vendor"),
+	)
+
+	if hv == "" {
+		hv = hypervisor(readTrim("/sys/hypervisor/type"))
+
+		// Xen's control domain (dom0) runs on the hypervisor, but
+		// isn't a guest
+		if (hv == "Xen") && strings.Contains(
+			readTrim("/proc/xen/capabilities"),
+			"control_d",
+		) {
+			return ""
+		}
+	}
+
+	if hv == "" {
+		hv = hypervisor(
+			readTrim("/proc/device-tree/hypervisor/compatible"),
+		)
+	}
+
+	if hv != "" {
+		return hv + " guest"
+	}
+
+	if reHypervisor.MatchString(readTrim("/proc/cpuinfo")) {
+		return "Unknown hypervisor guest"
+	}
+
+	return ""
+}
@@ -0,0 +1,78 @@
+package sysinfo
+
+import "strings"
+
+// Vulnerability is a struct containing the kernel's assessment of a
+// single CPU vulnerability.
+type Vulnerability struct {
+	Name   string `json:"name"`
+	State  string `json:"state"`
+	Status string `json:"status"`
+}
+
+// Vulns is a struct containing the CPU vulnerability and mitigation
+// status, along with the microcode revision.
+type Vulns struct {
+	Entries   []Vulnerability `json:"entries"`
+	Microcode string          `json:"microcode,omitempty"`
+}
+
+// String will return a summary of the Vulns, listing only the
+// entries that are vulnerable, partially mitigated, or could not be
+// assessed.
+func (v Vulns) String() string {
+	var order []string = []string{"vulnerable", "partial", "unknown"}
+	var out []string
+	var states map[string][]string = map[string][]string{}
+
+	for _, vuln := range v.Entries {
+		states[vuln.State] = append(states[vuln.State], vuln.Name)
+	}
+
+	for _, state := range order {
+		if len(states[state]) > 0 {
+			out = append(
+				out,
+				state+": "+strings.Join(states[state], ", "),
+			)
+		}
+	}
+
+	if len(out) == 0 {
+		out = append(out, "all mitigated")
+	}
+
+	if v.Microcode != "" {
+		return strings.Join(out, "; ") + " (microcode " +
+			v.Microcode + ")"
+	}
+
+	return strings.Join(out, "; ")
+}
+
+// vulnState will classify the status reported by the kernel as
+// "mitigated", "not affected", "partial", "vulnerable", or
+// "unknown". A mitigation with any remaining vulnerability, such as
+// "BHI: Vulnerable" or "SMT vulnerable", is only partial.
+func vulnState(status string) string {
+	var vulnerable bool = strings.Contains(
+		strings.ToLower(status),
+		"vulnerable",
+	)
+
+	// Such as itlb_multihit, which reports the state of KVM
+	status = strings.TrimPrefix(status, "KVM: ")
+
+	switch {
+	case strings.HasPrefix(status, "Mitigation") && vulnerable:
+		return "partial"
+	case strings.HasPrefix(status, "Mitigation"):
+		return "mitigated"
+	case strings.HasPrefix(status, "Not affected"):
+		return "not affected"
+	case vulnerable:
+		return "vulnerable"
+	default:
+		return "unknown"
+	}
+}
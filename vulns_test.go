@@ -0,0 +1,29 @@
+package sysinfo
+
+import "testing"
+
+func TestVulnState(t *testing.T) {
+	var tests map[string]string = map[string]string{
+		"KVM: Mitigation: Split huge pages":         "mitigated",
+		"KVM: Mitigation: VMX disabled":             "mitigated",
+		"KVM: Vulnerable":                           "vulnerable",
+		"Mitigation: IBRS; BHI: Vulnerable":         "partial",
+		"Mitigation: PTI":                           "mitigated",
+		"Mitigation: Clear buffers; SMT vulnerable": "partial",
+		"Not affected":                              "not affected",
+		"Processor vulnerable":                      "vulnerable",
+		"Unknown: No mitigations":                   "unknown",
+		"Vulnerable: No microcode":                  "vulnerable",
+	}
+
+	for status, expected := range tests {
+		if state := vulnState(status); state != expected {
+			t.Errorf(
+				"vulnState(%q) = %q, want %q",
+				status,
+				state,
+				expected,
+			)
+		}
+	}
+}
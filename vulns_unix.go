@@ -0,0 +1,42 @@
+//go:build !darwin && !windows
+
+package sysinfo
+
+import (
+	"os"
+	"path/filepath"
+)
+
+func (s *SysInfo) vulns() {
+	var dir string = "/sys/devices/system/cpu/vulnerabilities"
+	var e error
+	var entries []os.DirEntry
+	var m []string
+	var status string
+
+	s.Vulns = nil
+
+	entries, e = os.ReadDir(dir)
+	if (e != nil) || (len(entries) == 0) {
+		return
+	}
+
+	s.Vulns = &Vulns{}
+
+	for _, entry := range entries {
+		status = readTrim(filepath.Join(dir, entry.Name()))
+		s.Vulns.Entries = append(
+			s.Vulns.Entries,
+			Vulnerability{
+				Name:   entry.Name(),
+				State:  vulnState(status),
+				Status: status,
+			},
+		)
+	}
+
+	m = reMicrocode.FindStringSubmatch(readTrim("/proc/cpuinfo"))
+	if len(m) > 1 {
+		s.Vulns.Microcode = m[1]
+	}
+}
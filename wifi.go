@@ -0,0 +1,88 @@
+package sysinfo
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Wireless is a struct containing details about a wireless
+// connection.
+type Wireless struct {
+	Band    string  `json:"band,omitempty"`
+	Freq    int     `json:"freq,omitempty"`
+	Name    string  `json:"name"`
+	Quality int     `json:"quality,omitempty"`
+	Rate    float64 `json:"rate,omitempty"`
+	Signal  int     `json:"signal,omitempty"`
+	SSID    string  `json:"ssid,omitempty"`
+}
+
+// String will return a string representation of the Wireless
+// connection.
+func (w Wireless) String() string {
+	var out []string
+	var tmp []string
+
+	if (w.SSID == "") && (w.Freq == 0) {
+		return w.Name + " disconnected"
+	}
+
+	// Hidden networks, or no scan results for the BSS
+	if w.SSID == "" {
+		out = append(out, w.Name)
+	} else {
+		out = append(out, w.Name+" ("+w.SSID+")")
+	}
+
+	if w.Freq > 0 {
+		out = append(
+			out,
+			w.Band+" "+strconv.Itoa(w.Freq)+"MHz",
+		)
+	}
+
+	if w.Signal != 0 {
+		tmp = append(tmp, strconv.Itoa(w.Signal)+"dBm")
+	}
+
+	if w.Quality > 0 {
+		tmp = append(tmp, strconv.Itoa(w.Quality)+"%")
+	}
+
+	out = append(out, strings.Join(tmp, " "))
+
+	if w.Rate > 0 {
+		out = append(
+			out,
+			strconv.FormatFloat(w.Rate, 'f', -1, 64)+"Mb/s",
+		)
+	}
+
+	return joinNonEmpty(", ", out...)
+}
+
+// wifiBand will return the band for the specified frequency in MHz.
+//
+//nolint:mnd // Frequency ranges
+func wifiBand(freq int) string {
+	switch {
+	case (freq >= 2400) && (freq < 2500):
+		return "2.4GHz"
+	case (freq >= 4900) && (freq < 5925):
+		return "5GHz"
+	case (freq >= 5925) && (freq < 7125):
+		return "6GHz"
+	case (freq >= 57000) && (freq < 71000):
+		return "60GHz"
+	default:
+		return ""
+	}
+}
+
+// wifiQuality will estimate the link quality, as a percentage, from
+// the signal strength in dBm.
+//
+//nolint:mnd // -100dBm is 0%, -50dBm is 100%
+func wifiQuality(signal int) int {
+	return min(max(2*(signal+100), 0), 100)
+}
@@ -0,0 +1,505 @@
+//go:build linux
+
+package sysinfo
+
+import (
+	"encoding/binary"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mjwhitta/errors"
+	"golang.org/x/sys/unix"
+)
+
+// nl80211 is a minimal generic netlink client for the nl80211
+// family.
+type nl80211 struct {
+	family uint16
+	fd     int
+	seq    uint32
+}
+
+// newNL80211 will open a generic netlink socket and resolve the
+// nl80211 family ID.
+func newNL80211() (*nl80211, error) {
+	var attrs map[uint16][]byte
+	var e error
+	var msgs [][]byte
+	var n *nl80211 = &nl80211{}
+	var sa *unix.SockaddrNetlink
+	var tv unix.Timeval = unix.NsecToTimeval(int64(time.Second))
+
+	n.fd, e = unix.Socket(
+		unix.AF_NETLINK,
+		unix.SOCK_RAW|unix.SOCK_CLOEXEC,
+		unix.NETLINK_GENERIC,
+	)
+	if e != nil {
+		return nil, errors.Newf(
+			"failed to open netlink socket: %w",
+			e,
+		)
+	}
+
+	// Never hang waiting on the kernel
+	e = unix.SetsockoptTimeval(
+		n.fd,
+		unix.SOL_SOCKET,
+		unix.SO_RCVTIMEO,
+		&tv,
+	)
+	if e != nil {
+		n.close()
+		return nil, errors.Newf("failed to set socket timeout: %w", e)
+	}
+
+	sa = &unix.SockaddrNetlink{Family: unix.AF_NETLINK}
+	if e = unix.Bind(n.fd, sa); e != nil {
+		n.close()
+		return nil, errors.Newf(
+			"failed to bind netlink socket: %w",
+			e,
+		)
+	}
+
+	msgs, e = n.request(
+		unix.GENL_ID_CTRL,
+		unix.CTRL_CMD_GETFAMILY,
+		0,
+		nlAttr(unix.CTRL_ATTR_FAMILY_NAME, []byte("nl80211\x00")),
+	)
+	if e != nil {
+		n.close()
+		return nil, errors.Newf("nl80211 is not available: %w", e)
+	}
+
+	if len(msgs) > 0 {
+		attrs = nlAttrs(msgs[0])
+	}
+
+	//nolint:mnd // Family ID is a u16
+	if len(attrs[unix.CTRL_ATTR_FAMILY_ID]) < 2 {
+		n.close()
+		return nil, errors.New("nl80211 family ID not found")
+	}
+
+	n.family = binary.NativeEndian.Uint16(
+		attrs[unix.CTRL_ATTR_FAMILY_ID],
+	)
+
+	return n, nil
+}
+
+// bss will populate the SSID and frequency of the provided Wireless
+// using the associated BSS from the most recent scan results.
+func (n *nl80211) bss(w *Wireless, idx []byte) {
+	var attrs map[uint16][]byte
+	var e error
+	var msgs [][]byte
+
+	msgs, e = n.request(
+		n.family,
+		unix.NL80211_CMD_GET_SCAN,
+		unix.NLM_F_DUMP,
+		idx,
+	)
+	if e != nil {
+		return
+	}
+
+	for _, msg := range msgs {
+		attrs = nlAttrs(nlAttrs(msg)[unix.NL80211_ATTR_BSS])
+
+		if nlUint32(attrs[unix.NL80211_BSS_STATUS]) !=
+			unix.NL80211_BSS_STATUS_ASSOCIATED {
+			continue
+		}
+
+		if w.Freq == 0 {
+			w.Freq = int(nlUint32(attrs[unix.NL80211_BSS_FREQUENCY]))
+		}
+
+		w.SSID = ieSSID(attrs[unix.NL80211_BSS_INFORMATION_ELEMENTS])
+
+		return
+	}
+}
+
+func (n *nl80211) close() {
+	_ = unix.Close(n.fd)
+}
+
+// details will populate the provided Wireless using nl80211.
+func (n *nl80211) details(w *Wireless) {
+	var attrs map[uint16][]byte
+	var e error
+	var iface *net.Interface
+	var idx []byte = make([]byte, 4) //nolint:mnd // u32
+	var msgs [][]byte
+
+	if iface, e = net.InterfaceByName(w.Name); e != nil {
+		return
+	}
+
+	binary.NativeEndian.PutUint32(idx, uint32(iface.Index))
+	idx = nlAttr(unix.NL80211_ATTR_IFINDEX, idx)
+
+	msgs, e = n.request(
+		n.family,
+		unix.NL80211_CMD_GET_INTERFACE,
+		0,
+		idx,
+	)
+	if (e == nil) && (len(msgs) > 0) {
+		attrs = nlAttrs(msgs[0])
+		w.Freq = int(nlUint32(attrs[unix.NL80211_ATTR_WIPHY_FREQ]))
+		w.SSID = string(attrs[unix.NL80211_ATTR_SSID])
+	}
+
+	// Older kernels don't report the SSID of the interface
+	if w.SSID == "" {
+		n.bss(w, idx)
+	}
+
+	n.station(w, idx)
+}
+
+// request will send a generic netlink command and return the
+// attributes from each reply.
+func (n *nl80211) request(
+	family uint16, cmd uint8, flags uint16, attrs []byte,
+) ([][]byte, error) {
+	var b []byte = make([]byte, unix.SizeofNlMsghdr+genlHdrLen)
+	var done bool
+	var e error
+	var out [][]byte
+	var replies [][]byte
+	var size int
+
+	n.seq++
+
+	binary.NativeEndian.PutUint32(b[0:], uint32(len(b)+len(attrs)))
+	binary.NativeEndian.PutUint16(b[4:], family)
+	binary.NativeEndian.PutUint16(b[6:], unix.NLM_F_REQUEST|flags)
+	binary.NativeEndian.PutUint32(b[8:], n.seq)
+	b[unix.SizeofNlMsghdr] = cmd
+	b[unix.SizeofNlMsghdr+1] = 1 // Version
+	b = append(b, attrs...)
+
+	e = unix.Sendto(
+		n.fd,
+		b,
+		0,
+		&unix.SockaddrNetlink{Family: unix.AF_NETLINK},
+	)
+	if e != nil {
+		return nil, errors.Newf(
+			"failed to send netlink request: %w",
+			e,
+		)
+	}
+
+	for {
+		// Replies are kept, so don't reuse the buffer
+		//nolint:mnd // 64KiB fits several scan results
+		b = make([]byte, 64*1024)
+
+		if size, _, e = unix.Recvfrom(n.fd, b, 0); e != nil {
+			return nil, errors.Newf(
+				"failed to read netlink reply: %w",
+				e,
+			)
+		}
+
+		replies, done, e = nlReplies(
+			b[:size],
+			genlHdrLen,
+			n.seq,
+			(flags&unix.NLM_F_DUMP) != 0,
+		)
+		if e != nil {
+			return nil, e
+		}
+
+		out = append(out, replies...)
+
+		if done {
+			return out, nil
+		}
+	}
+}
+
+// station will populate the signal and link rate of the provided
+// Wireless using the station info of the access point.
+func (n *nl80211) station(w *Wireless, idx []byte) {
+	var e error
+	var info map[uint16][]byte
+	var msgs [][]byte
+	var rate map[uint16][]byte
+	var tmp []byte
+
+	msgs, e = n.request(
+		n.family,
+		unix.NL80211_CMD_GET_STATION,
+		unix.NLM_F_DUMP,
+		idx,
+	)
+	if (e != nil) || (len(msgs) == 0) {
+		return
+	}
+
+	// In managed mode, the only station is the access point
+	info = nlAttrs(nlAttrs(msgs[0])[unix.NL80211_ATTR_STA_INFO])
+
+	if tmp = info[unix.NL80211_STA_INFO_SIGNAL]; len(tmp) > 0 {
+		w.Signal = int(int8(tmp[0]))
+	}
+
+	// Bitrates are in units of 100kb/s
+	rate = nlAttrs(info[unix.NL80211_STA_INFO_TX_BITRATE])
+
+	//nolint:mnd // 100kb/s per unit
+	if tmp = rate[unix.NL80211_RATE_INFO_BITRATE32]; len(tmp) >= 4 {
+		w.Rate = float64(binary.NativeEndian.Uint32(tmp)) / 10
+		return
+	}
+
+	//nolint:mnd // 100kb/s per unit
+	if tmp = rate[unix.NL80211_RATE_INFO_BITRATE]; len(tmp) >= 2 {
+		w.Rate = float64(binary.NativeEndian.Uint16(tmp)) / 10
+	}
+}
+
+func (s *SysInfo) wifi() {
+	var e error
+	var n *nl80211
+	var names []string
+	var wifis map[string]*Wireless = wirelessInterfaces()
+
+	s.WiFi = nil
+
+	if len(wifis) == 0 {
+		return
+	}
+
+	// Without nl80211, only wireless extensions details are shown
+	if n, e = newNL80211(); e == nil {
+		defer n.close()
+	}
+
+	for name := range wifis {
+		if keepInterface(name) {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if n != nil {
+			n.details(wifis[name])
+		}
+
+		if (wifis[name].Quality == 0) && (wifis[name].Signal < 0) {
+			wifis[name].Quality = wifiQuality(wifis[name].Signal)
+		}
+
+		wifis[name].Band = wifiBand(wifis[name].Freq)
+
+		s.WiFi = append(s.WiFi, *wifis[name])
+	}
+}
+
+// ieSSID will return the SSID from the provided 802.11 information
+// elements.
+func ieSSID(ies []byte) string {
+	var l int
+
+	//nolint:mnd // ID and length are 1 byte each
+	for len(ies) >= 2 {
+		if l = int(ies[1]); 2+l > len(ies) {
+			break
+		}
+
+		// SSID is element ID 0
+		if ies[0] == 0 {
+			return string(ies[2 : 2+l])
+		}
+
+		ies = ies[2+l:]
+	}
+
+	return ""
+}
+
+// nlAlign will round the provided length up to the netlink
+// alignment.
+func nlAlign(l int) int {
+	return (l + unix.NLMSG_ALIGNTO - 1) &^ (unix.NLMSG_ALIGNTO - 1)
+}
+
+// nlAttr will encode a netlink attribute.
+func nlAttr(typ uint16, val []byte) []byte {
+	var l int = unix.SizeofNlAttr + len(val)
+	var b []byte = make([]byte, nlAlign(l))
+
+	binary.NativeEndian.PutUint16(b[0:], uint16(l))
+	binary.NativeEndian.PutUint16(b[2:], typ)
+	copy(b[unix.SizeofNlAttr:], val)
+
+	return b
+}
+
+// nlAttrs will decode the provided netlink attributes, keyed by type.
+func nlAttrs(b []byte) map[uint16][]byte {
+	var l int
+	var out map[uint16][]byte = map[uint16][]byte{}
+	var typ uint16
+
+	for len(b) >= unix.SizeofNlAttr {
+		l = int(binary.NativeEndian.Uint16(b[0:]))
+		if (l < unix.SizeofNlAttr) || (l > len(b)) {
+			break
+		}
+
+		typ = binary.NativeEndian.Uint16(b[2:]) & nlaTypeMask
+		out[typ] = b[unix.SizeofNlAttr:l]
+
+		b = b[min(nlAlign(l), len(b)):]
+	}
+
+	return out
+}
+
+// nlReplies will return the attributes from each netlink message in
+// the provided datagram with the specified sequence number, skipping
+// the family header of the specified length, and whether or not the
+// reply is complete.
+func nlReplies(
+	msg []byte, hdrLen int, seq uint32, dump bool,
+) ([][]byte, bool, error) {
+	var body []byte
+	var errno int32
+	var out [][]byte
+	var size int
+
+	for len(msg) >= unix.SizeofNlMsghdr {
+		size = int(binary.NativeEndian.Uint32(msg[0:]))
+		if (size < unix.SizeofNlMsghdr) || (size > len(msg)) {
+			return nil, false, errors.New("truncated netlink reply")
+		}
+
+		body = msg[unix.SizeofNlMsghdr:size]
+
+		if binary.NativeEndian.Uint32(msg[8:]) != seq {
+			msg = msg[min(nlAlign(size), len(msg)):]
+			continue
+		}
+
+		switch binary.NativeEndian.Uint16(msg[4:]) {
+		case unix.NLMSG_DONE:
+			return out, true, nil
+		case unix.NLMSG_ERROR:
+			// Negative errno, or 0 for an ACK
+			//nolint:mnd // Errno is an i32
+			if len(body) >= 4 {
+				errno = int32(binary.NativeEndian.Uint32(body))
+				if errno != 0 {
+					return nil, false, unix.Errno(-errno)
+				}
+			}
+
+			return out, true, nil
+		}
+
+		if len(body) >= hdrLen {
+			out = append(out, body[hdrLen:])
+		}
+
+		if !dump {
+			return out, true, nil
+		}
+
+		msg = msg[min(nlAlign(size), len(msg)):]
+	}
+
+	return out, false, nil
+}
+
+// nlUint32 will decode a u32 netlink attribute.
+func nlUint32(b []byte) uint32 {
+	//nolint:mnd // u32 is 4 bytes
+	if len(b) < 4 {
+		return 0
+	}
+
+	return binary.NativeEndian.Uint32(b)
+}
+
+// wirelessInterfaces will return the wireless interfaces, along with
+// their link quality and signal level from /proc/net/wireless, if
+// available.
+func wirelessInterfaces() map[string]*Wireless {
+	var cols []string
+	var e error
+	var entries []os.DirEntry
+	var name string
+	var out map[string]*Wireless = map[string]*Wireless{}
+	var tmp float64
+
+	// Only drivers with wireless extensions are listed here
+	for _, line := range strings.Split(
+		readTrim(procNetWireless),
+		"\n",
+	) {
+		// Iface: Status Link Level Noise ...
+		//nolint:mnd // Need at least 5 columns
+		if cols = strings.Fields(line); len(cols) < 5 {
+			continue
+		}
+
+		if !strings.HasSuffix(cols[0], ":") {
+			// Header
+			continue
+		}
+
+		name = strings.TrimSuffix(cols[0], ":")
+		out[name] = &Wireless{Name: name}
+
+		// A trailing "." means the value was updated
+		cols[2] = strings.TrimRight(cols[2], ".")
+		cols[3] = strings.TrimRight(cols[3], ".")
+
+		// Link quality is usually out of 70
+		tmp, e = strconv.ParseFloat(cols[2], 64)
+		if (e == nil) && (tmp > 0) {
+			//nolint:mnd // Convert to percentage
+			out[name].Quality = min(int(tmp*100/70), 100)
+		}
+
+		tmp, e = strconv.ParseFloat(cols[3], 64)
+		if (e == nil) && (tmp < 0) {
+			out[name].Signal = int(tmp)
+		}
+	}
+
+	// Catch any wireless interfaces without wireless extensions
+	entries, _ = os.ReadDir(sysClassNet)
+	for _, entry := range entries {
+		if _, ok := out[entry.Name()]; ok {
+			continue
+		}
+
+		name = filepath.Join(sysClassNet, entry.Name())
+		if interfaceType(name) == "wifi" {
+			out[entry.Name()] = &Wireless{Name: entry.Name()}
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,250 @@
+//go:build linux
+
+package sysinfo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/sys/unix"
+)
+
+// testGenlMsg will return a generic netlink message with the
+// provided sequence number and attributes.
+func testGenlMsg(seq uint32, attrs []byte) []byte {
+	var b []byte = append(make([]byte, genlHdrLen), attrs...)
+
+	return testNLMsg(0x1c, seq, b) //nolint:mnd // Any family ID
+}
+
+// testNLMsg will return a netlink message of the provided type and
+// sequence number.
+func testNLMsg(typ uint16, seq uint32, body []byte) []byte {
+	var b []byte = make([]byte, unix.SizeofNlMsghdr)
+
+	b = append(b, body...)
+	binary.NativeEndian.PutUint32(b[0:], uint32(len(b)))
+	binary.NativeEndian.PutUint16(b[4:], typ)
+	binary.NativeEndian.PutUint32(b[8:], seq)
+
+	return append(b, make([]byte, nlAlign(len(b))-len(b))...)
+}
+
+func TestIESSID(t *testing.T) {
+	var tests map[string][]byte = map[string][]byte{
+		"":       {},
+		"home":   {0, 4, 'h', 'o', 'm', 'e', 1, 1, 0x82},
+		"hidden": {1, 1, 0x82, 0, 6, 'h', 'i', 'd', 'd', 'e', 'n'},
+		"x":      {0, 1, 'x', 1, 8},
+	}
+
+	for expected, ies := range tests {
+		if ssid := ieSSID(ies); ssid != expected {
+			t.Errorf("got %q, want %q", ssid, expected)
+		}
+	}
+
+	// Truncated elements
+	for _, ies := range [][]byte{
+		{0},
+		{0, 4, 'h', 'o'},
+		{1, 200, 0, 4, 'h', 'o', 'm', 'e'},
+	} {
+		if ssid := ieSSID(ies); ssid != "" {
+			t.Errorf("%v: got %q, want \"\"", ies, ssid)
+		}
+	}
+}
+
+func TestNLAttrs(t *testing.T) {
+	var attrs map[uint16][]byte
+	var b []byte
+	var freq []byte = binary.NativeEndian.AppendUint32(nil, 2437)
+	var nested []byte
+
+	b = nlAttr(unix.NL80211_ATTR_SSID, []byte("home"))
+	b = append(b, nlAttr(unix.NL80211_ATTR_WIPHY_FREQ, freq)...)
+
+	// Nested attributes have the NLA_F_NESTED flag set
+	nested = nlAttr(unix.NL80211_ATTR_BSS|unix.NLA_F_NESTED, b)
+
+	attrs = nlAttrs(b)
+
+	if string(attrs[unix.NL80211_ATTR_SSID]) != "home" {
+		t.Errorf("got SSID %q", attrs[unix.NL80211_ATTR_SSID])
+	}
+
+	if nlUint32(attrs[unix.NL80211_ATTR_WIPHY_FREQ]) != 2437 {
+		t.Errorf("got freq %v", attrs[unix.NL80211_ATTR_WIPHY_FREQ])
+	}
+
+	attrs = nlAttrs(nlAttrs(nested)[unix.NL80211_ATTR_BSS])
+
+	if !bytes.Equal(attrs[unix.NL80211_ATTR_WIPHY_FREQ], freq) {
+		t.Errorf("got nested %v", attrs)
+	}
+
+	// Truncated attributes are dropped rather than panicking
+	for i := range len(b) {
+		attrs = nlAttrs(b[:i])
+
+		if (i < 8) && (len(attrs) != 0) {
+			t.Errorf("%d bytes: got %v", i, attrs)
+		}
+
+		for typ, val := range attrs {
+			if typ != unix.NL80211_ATTR_SSID {
+				continue
+			}
+
+			if string(val) != "home" {
+				t.Errorf("%d bytes: got SSID %q", i, val)
+			}
+		}
+	}
+
+	// Length shorter than the header
+	if attrs = nlAttrs([]byte{2, 0, 1, 0, 0, 0}); len(attrs) != 0 {
+		t.Errorf("got %v", attrs)
+	}
+}
+
+func TestNLReplies(t *testing.T) {
+	var done bool
+	var e error
+	var enodev []byte = make([]byte, 4)
+	var errno int32 = -int32(unix.ENODEV)
+	var first []byte = testGenlMsg(7, nlAttr(1, []byte("a")))
+	var msg []byte
+	var replies [][]byte
+
+	binary.NativeEndian.PutUint32(enodev, uint32(errno))
+
+	msg = append(msg, first...)
+	msg = append(msg, testGenlMsg(6, nlAttr(1, []byte("x")))...)
+	msg = append(msg, testGenlMsg(7, nlAttr(1, []byte("b")))...)
+
+	// Dump without NLMSG_DONE continues in the next datagram
+	replies, done, e = nlReplies(msg, genlHdrLen, 7, true)
+	if (e != nil) || done || (len(replies) != 2) {
+		t.Errorf(
+			"dump: got %d replies, %t, %v",
+			len(replies),
+			done,
+			e,
+		)
+	}
+
+	replies, done, e = nlReplies(
+		append(msg, testNLMsg(unix.NLMSG_DONE, 7, nil)...),
+		genlHdrLen,
+		7,
+		true,
+	)
+	if (e != nil) || !done || (len(replies) != 2) {
+		t.Errorf(
+			"done: got %d replies, %t, %v",
+			len(replies),
+			done,
+			e,
+		)
+	}
+
+	// Requests without a dump stop at the first reply
+	replies, done, e = nlReplies(msg, genlHdrLen, 7, false)
+	if (e != nil) || !done || (len(replies) != 1) {
+		t.Errorf(
+			"single: got %d replies, %t, %v",
+			len(replies),
+			done,
+			e,
+		)
+	}
+
+	// Truncated messages
+	for i := 1; i < len(msg); i++ {
+		replies, _, e = nlReplies(msg[:i], genlHdrLen, 7, true)
+
+		// Header length exceeds the datagram
+		if (i >= unix.SizeofNlMsghdr) && (i < len(first)) {
+			if e == nil {
+				t.Errorf("%d bytes: expected error", i)
+			}
+		}
+
+		for _, reply := range replies {
+			if string(nlAttrs(reply)[1]) != "a" {
+				t.Errorf("%d bytes: got %v", i, reply)
+			}
+		}
+	}
+
+	msg = testNLMsg(unix.NLMSG_ERROR, 7, enodev)
+
+	_, _, e = nlReplies(msg, genlHdrLen, 7, true)
+	if !errors.Is(e, unix.ENODEV) {
+		t.Errorf("error: got %v, want %v", e, unix.ENODEV)
+	}
+}
+
+func TestNLUint32(t *testing.T) {
+	var tests [][]byte = [][]byte{nil, {}, {1}, {1, 2, 3}}
+
+	for _, b := range tests {
+		if n := nlUint32(b); n != 0 {
+			t.Errorf("%v: got %d, want 0", b, n)
+		}
+	}
+
+	n := nlUint32(binary.NativeEndian.AppendUint32(nil, 5))
+	if n != 5 {
+		t.Errorf("got %d, want 5", n)
+	}
+}
+
+func TestWirelessInterfaces(t *testing.T) {
+	var e error
+	var tmp string = t.TempDir()
+	var wifis map[string]*Wireless
+
+	swap(t, &procNetWireless, filepath.Join(tmp, "wireless"))
+	swap(t, &sysClassNet, filepath.Join(tmp, "net"))
+
+	e = os.WriteFile(
+		procNetWireless,
+		[]byte(
+			"Inter-| sta-|   Quality        |   Discarded\n"+
+				" face | tus | link level noise |  nwid  crypt\n"+
+				" wlan0: 0000   54.  -56.  -256        0      0\n"+
+				" wlan1: 0000    0     0     0         0      0\n",
+		),
+		0o600,
+	)
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	wifis = wirelessInterfaces()
+
+	if len(wifis) != 2 {
+		t.Fatalf("got %d interfaces, want 2", len(wifis))
+	}
+
+	// Link quality is out of 70
+	if wifis["wlan0"].Quality != 77 {
+		t.Errorf("got quality %d, want 77", wifis["wlan0"].Quality)
+	}
+
+	if wifis["wlan0"].Signal != -56 {
+		t.Errorf("got signal %d, want -56", wifis["wlan0"].Signal)
+	}
+
+	// No link
+	if (wifis["wlan1"].Quality != 0) || (wifis["wlan1"].Signal != 0) {
+		t.Errorf("got %+v", *wifis["wlan1"])
+	}
+}
@@ -0,0 +1,8 @@
+//go:build !linux
+
+package sysinfo
+
+// wifi is not supported on this platform.
+func (s *SysInfo) wifi() {
+	s.WiFi = nil
+}
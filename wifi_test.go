@@ -0,0 +1,30 @@
+package sysinfo
+
+import "testing"
+
+func TestWirelessString(t *testing.T) {
+	var tests map[string]Wireless = map[string]Wireless{
+		"wlan0 disconnected": {Name: "wlan0"},
+		"wlan0 (home), 5GHz 5180MHz, -56dBm 77%, 866.7Mb/s": {
+			Band:    "5GHz",
+			Freq:    5180,
+			Name:    "wlan0",
+			Quality: 77,
+			Rate:    866.7,
+			Signal:  -56,
+			SSID:    "home",
+		},
+		"wlan0, 2.4GHz 2437MHz, -60dBm": {
+			Band:   "2.4GHz",
+			Freq:   2437,
+			Name:   "wlan0",
+			Signal: -60,
+		},
+	}
+
+	for expected, w := range tests {
+		if w.String() != expected {
+			t.Errorf("got %q, want %q", w.String(), expected)
+		}
+	}
+}
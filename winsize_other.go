@@ -0,0 +1,15 @@
+//go:build !unix && !windows
+
+package sysinfo
+
+import "os"
+
+// IsTerminal is not supported on this platform.
+func IsTerminal(_ *os.File) bool {
+	return false
+}
+
+// termSize is not supported on this platform.
+func termSize(_ string) (int, int, bool) {
+	return 0, 0, false
+}
@@ -0,0 +1,52 @@
+//go:build unix
+
+package sysinfo
+
+import (
+	"os"
+	"path/filepath"
+
+	"golang.org/x/sys/unix"
+)
+
+// IsTerminal will return whether or not the provided file is a
+// terminal.
+func IsTerminal(f *os.File) bool {
+	var e error
+
+	_, e = unix.IoctlGetTermios(int(f.Fd()), ioctlGetTermios)
+
+	return e == nil
+}
+
+// termSize will return the columns and rows of the specified TTY,
+// falling back to stdout, stdin, and then stderr.
+func termSize(tty string) (int, int, bool) {
+	var e error
+	var f *os.File
+	var fds []uintptr = []uintptr{
+		os.Stdout.Fd(),
+		os.Stdin.Fd(),
+		os.Stderr.Fd(),
+	}
+	var ws *unix.Winsize
+
+	if tty != "" {
+		if f, e = os.Open(filepath.Clean(tty)); e == nil {
+			defer func() {
+				_ = f.Close()
+			}()
+
+			fds = append([]uintptr{f.Fd()}, fds...)
+		}
+	}
+
+	for _, fd := range fds {
+		ws, e = unix.IoctlGetWinsize(int(fd), unix.TIOCGWINSZ)
+		if (e == nil) && (ws.Col > 0) {
+			return int(ws.Col), int(ws.Row), true
+		}
+	}
+
+	return 0, 0, false
+}
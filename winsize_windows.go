@@ -0,0 +1,44 @@
+//go:build windows
+
+package sysinfo
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// IsTerminal will return whether or not the provided file is a
+// console.
+func IsTerminal(f *os.File) bool {
+	var e error
+	var mode uint32
+
+	e = windows.GetConsoleMode(windows.Handle(f.Fd()), &mode)
+
+	return e == nil
+}
+
+// termSize will return the columns and rows of the console. The TTY
+// is ignored on Windows.
+func termSize(_ string) (int, int, bool) {
+	var e error
+	var info windows.ConsoleScreenBufferInfo
+
+	for _, h := range []windows.Handle{
+		windows.Stdout,
+		windows.Stdin,
+		windows.Stderr,
+	} {
+		e = windows.GetConsoleScreenBufferInfo(h, &info)
+		if e != nil {
+			continue
+		}
+
+		return int(info.Window.Right-info.Window.Left) + 1,
+			int(info.Window.Bottom-info.Window.Top) + 1,
+			true
+	}
+
+	return 0, 0, false
+}